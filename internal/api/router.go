@@ -1,39 +1,179 @@
 package api
 
 import (
+	"log"
+
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"yp-blog-api/internal/controller"
+	"yp-blog-api/internal/middleware"
+	"yp-blog-api/internal/pubsub"
 	"yp-blog-api/internal/service"
 )
 
 // SetupRouter initializes the Gin router with all the routes and dependencies
-func SetupRouter(blogService service.BlogService) *gin.Engine {
+// authenticate resolves the signed-in user of each request, see middleware.Authenticate.
+// bus carries the live events streamed to clients.
+// trustedProxies and clientIPHeader tell where the client IP comes from, see below.
+func SetupRouter(blogService service.BlogService, commentService service.CommentService, reactionService service.ReactionService,
+	bookmarkService service.BookmarkService, followService service.FollowService, notificationService service.NotificationService,
+	webhookService service.WebhookService, jobQueue service.JobQueue, syndicationService service.SyndicationService,
+	sitemapService service.SitemapService, seoService service.SeoService,
+	oEmbedService service.OEmbedService, mediaService service.MediaService, maxUploadBytes int64,
+	bannerService service.AdvertisingBannerService, bus *pubsub.Bus, authenticate gin.HandlerFunc,
+	trustedProxies []string, clientIPHeader string) *gin.Engine {
 	// Set up the Gin router
 	router := gin.Default()
+	// Client IPs, which comments are rate limited by, are only taken from X-Forwarded-For
+	// when the request comes from one of the trusted proxies, and otherwise from the
+	// connection. Platforms whose edge sets a header of its own, like Fly-Client-IP on
+	// Fly.io, are trusted through that header instead.
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		log.Printf("Error occurred while setting the trusted proxies, trusting none: %v", err)
+		router.SetTrustedProxies(nil)
+	}
+	router.TrustedPlatform = clientIPHeader
+	router.Use(authenticate)
 	//add swagger
 	router.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	// Initialize the controller with the service
 	blogController := controller.NewBlogController(blogService)
-	//authorController := controller.NewAuthorController(blogService)
-	adminController := controller.NewAdminController(blogService)
+	authorController := controller.NewAuthorController(blogService, followService)
+	adminController := controller.NewAdminController(blogService, commentService)
+	commentController := controller.NewCommentController(commentService)
+	reactionController := controller.NewReactionController(reactionService)
+	bookmarkController := controller.NewBookmarkController(bookmarkService)
+	followController := controller.NewFollowController(followService)
+	notificationController := controller.NewNotificationController(notificationService)
+	streamController := controller.NewStreamController(bus)
+	webhookController := controller.NewWebhookController(webhookService)
+	jobController := controller.NewJobController(jobQueue)
+	feedController := controller.NewFeedController(syndicationService)
+	sitemapController := controller.NewSitemapController(sitemapService)
+	seoController := controller.NewSeoController(seoService)
+	oEmbedController := controller.NewOEmbedController(oEmbedService)
+	mediaController := controller.NewMediaController(mediaService, maxUploadBytes)
+	bannerController := controller.NewAdvertisingBannerController(bannerService)
 
 	// Define the routes
 	router.GET("/api/blogs-admin", blogController.GetAllBlogs)
 	router.GET("/api/blogs-admin/:id", blogController.GetBlogById)
-	router.PUT("/api/blogs/:slug", blogController.UpdateBlog)
+	router.PUT("/api/blogs/@:author/:slug", blogController.UpdateBlog)
 	router.DELETE("/api/blogs-admin/:id", blogController.DeleteBlog)
+	router.DELETE("/api/blogs/:id", blogController.DeleteBlogByChangeStatus)
 
 	// project api blog
 	router.GET("/api/blogs/:categoriesSlug", blogController.ListAllByCategoriesSlug)
 	router.GET("/api/blogs/", blogController.ListAllByCategoriesSlug)
 	router.GET("/api/blogs/@:author/:slug", blogController.GetBlogDetailByAuthorAndSlug) // Updated route
+	router.GET("/api/blogs/@:author/:slug/related", blogController.GetRelatedBlogs)
+	router.GET("/api/blogs/@:author/:slug/seo", seoController.GetSeoMetadata)
 	router.POST("/api/blogs", blogController.CreateBlog)
 	router.GET("/api/blogs/recent-posts", blogController.GetRecentPosts)
 	router.GET("/api/blogs/category/:slug/top6", blogController.Find6BlogsByCategoriesSlug)
 	router.GET("/api/blogs/user/:username/top6", blogController.Find6BlogsByUsernameAndCountViewer)
 	router.GET("/api/admin/blogs", adminController.GetAllBlogsForAdmin)
+	// the moderation queue shows commenter IPs, so it is for administrators; authors
+	// manage the moderation rule of their own blogs
+	admin := router.Group("/api/admin", middleware.RequireAdmin())
+	admin.GET("/comments", adminController.GetCommentModerationQueue)
+	admin.PUT("/comments/:id/status", adminController.ModerateComment)
+	authorOrAdmin := middleware.RequireSelfOrAdmin("username")
+	router.GET("/api/admin/authors/:username/comment-rule", authorOrAdmin, adminController.GetCommentModerationRule)
+	router.PUT("/api/admin/authors/:username/comment-rule", authorOrAdmin, adminController.SaveCommentModerationRule)
+	// webhooks are site-wide and expose subscribers, secrets and payloads
+	admin.GET("/webhooks", webhookController.GetWebhooks)
+	admin.POST("/webhooks", webhookController.CreateWebhook)
+	admin.GET("/webhooks/:id", webhookController.GetWebhook)
+	admin.PUT("/webhooks/:id", webhookController.UpdateWebhook)
+	admin.DELETE("/webhooks/:id", webhookController.DeleteWebhook)
+	admin.GET("/webhooks/:id/deliveries", webhookController.GetDeliveries)
+	admin.GET("/webhooks/:id/deliveries/:deliveryId", webhookController.GetDelivery)
+	admin.POST("/webhooks/:id/deliveries/:deliveryId/redeliver", webhookController.Redeliver)
+	// background jobs expose payloads and errors
+	admin.GET("/jobs", jobController.GetJobs)
+	admin.GET("/jobs/:id", jobController.GetJob)
+	admin.POST("/jobs/:id/retry", jobController.RetryJob)
+	admin.POST("/sitemap/rebuild", sitemapController.RebuildSitemap)
+	// banner campaigns and their sponsor reports are managed by administrators
+	admin.GET("/banners", bannerController.GetBannersForAdmin)
+	admin.GET("/banners/report", bannerController.GetBannerReports)
+	admin.POST("/banners", bannerController.CreateBanner)
+	admin.GET("/banners/:id", bannerController.GetBannerForAdmin)
+	admin.PUT("/banners/:id", bannerController.UpdateBanner)
+	admin.DELETE("/banners/:id", bannerController.DeleteBanner)
+	admin.POST("/banners/:id/restore", bannerController.RestoreBanner)
+	admin.GET("/banners/:id/report", bannerController.GetBannerReport)
+
+	// feeds
+	router.GET("/feed.xml", feedController.GetRSSFeed)
+	router.GET("/atom.xml", feedController.GetAtomFeed)
+	router.GET("/feed.json", feedController.GetJSONFeed)
+
+	// oEmbed
+	router.GET("/oembed", oEmbedController.GetEmbed)
+
+	// sitemap
+	router.GET("/sitemap.xml", sitemapController.GetSitemapIndex)
+	router.GET("/sitemaps/:page", sitemapController.GetSitemap)
+
+	// banners
+	router.GET("/api/banners", bannerController.GetBanners)
+	router.GET("/api/banners/:id", bannerController.GetBanner)
+	router.GET("/r/banner/:id", bannerController.RedirectBanner)
+
+	// comments
+	router.GET("/api/blogs/@:author/:slug/comments", commentController.ListComments)
+	router.POST("/api/blogs/@:author/:slug/comments", commentController.CreateComment)
+	router.PUT("/api/blogs/@:author/:slug/comments/:id", commentController.UpdateComment)
+	router.DELETE("/api/blogs/@:author/:slug/comments/:id", commentController.DeleteComment)
+
+	// reactions
+	router.GET("/api/blogs/@:author/:slug/reactions", reactionController.GetReactions)
+	router.PUT("/api/blogs/@:author/:slug/reactions/:type", reactionController.AddReaction)
+	router.DELETE("/api/blogs/@:author/:slug/reactions/:type", reactionController.RemoveReaction)
+
+	// bookmarks and reading lists of the signed-in reader
+	me := router.Group("/api/me", middleware.RequireUser())
+	me.GET("/bookmarks", bookmarkController.GetBookmarks)
+	me.PUT("/bookmarks/order", bookmarkController.ReorderBookmarks)
+	me.PUT("/bookmarks/@:author/:slug", bookmarkController.AddBookmark)
+	me.DELETE("/bookmarks/@:author/:slug", bookmarkController.RemoveBookmark)
+	me.GET("/reading-lists", bookmarkController.GetReadingLists)
+	me.POST("/reading-lists", bookmarkController.CreateReadingList)
+	me.GET("/reading-lists/:id", bookmarkController.GetReadingList)
+	me.PUT("/reading-lists/:id", bookmarkController.RenameReadingList)
+	me.DELETE("/reading-lists/:id", bookmarkController.DeleteReadingList)
+	me.PUT("/reading-lists/:id/order", bookmarkController.ReorderReadingList)
+	me.PUT("/reading-lists/:id/blogs/@:author/:slug", bookmarkController.AddToReadingList)
+	me.DELETE("/reading-lists/:id/blogs/@:author/:slug", bookmarkController.RemoveFromReadingList)
+
+	// media library of the signed-in user
+	me.POST("/media", mediaController.UploadMedia)
+	me.GET("/media", mediaController.GetMediaLibrary)
+	me.GET("/media/:id", mediaController.GetMedia)
+	me.DELETE("/media/:id", mediaController.DeleteMedia)
+
+	// follows and the personalized feed
+	router.GET("/api/authors/:username", authorController.GetAuthorProfile)
+	router.GET("/api/feed", middleware.RequireUser(), followController.GetFeed)
+	me.GET("/following", followController.GetFollowing)
+	me.PUT("/following/authors/:username", followController.FollowAuthor)
+	me.DELETE("/following/authors/:username", followController.UnfollowAuthor)
+	me.PUT("/following/tags/:id", followController.FollowTag)
+	me.DELETE("/following/tags/:id", followController.UnfollowTag)
+
+	// notifications of the signed-in user
+	me.GET("/notifications", notificationController.GetNotifications)
+	me.GET("/notifications/unread-count", notificationController.GetUnreadCount)
+	me.PUT("/notifications/read-all", notificationController.MarkAllRead)
+	me.PUT("/notifications/:id/read", notificationController.MarkRead)
+	me.GET("/notification-preferences", notificationController.GetPreferences)
+	me.PUT("/notification-preferences", notificationController.UpdatePreferences)
+
+	// live events
+	router.GET("/api/stream", streamController.Stream)
 
 	return router
 }
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"gorm.io/driver/sqlite"
@@ -23,8 +24,19 @@ func InitDatabase() {
 		dbPath = "./data/test.db" // Default path if the environment variable is not set
 	}
 
+	// The job workers, the outbox and webhook tickers and the HTTP handlers all write to
+	// this file. Writers wait up to five seconds for each other instead of failing with
+	// SQLITE_BUSY, and WAL mode lets reads go on while one of them writes.
+	dsn := dbPath
+	if strings.Contains(dsn, "?") {
+		dsn += "&"
+	} else {
+		dsn += "?"
+	}
+	dsn += "_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+
 	// Use "sqlite" as the driver name with modernc.org/sqlite
-	sqlDB, err := sql.Open("sqlite", dbPath)
+	sqlDB, err := sql.Open("sqlite", dsn)
 	if err != nil {
 		log.Fatalf("Failed to open SQLite database: %v", err)
 	}
@@ -4,17 +4,22 @@ import (
 	"github.com/gin-gonic/gin"
 	"log"
 	"net/http"
+	"strconv"
+	"yp-blog-api/internal/dto"
+	"yp-blog-api/internal/handler"
 	"yp-blog-api/internal/service"
 )
 
 type AdminController struct {
-	blogService service.BlogService
+	blogService    service.BlogService
+	commentService service.CommentService
 }
 
 // NewAdminController NewBlogController creates a new BlogController
-func NewAdminController(blogService service.BlogService) *AdminController {
+func NewAdminController(blogService service.BlogService, commentService service.CommentService) *AdminController {
 	return &AdminController{
-		blogService: blogService,
+		blogService:    blogService,
+		commentService: commentService,
 	}
 }
 
@@ -39,3 +44,109 @@ func (ctrl *AdminController) GetAllBlogsForAdmin(c *gin.Context) {
 	log.Printf("Successfully retrieved %d blogs for admin", len(blogs))
 	c.JSON(http.StatusOK, blogs)
 }
+
+// GetCommentModerationQueue godoc
+// @Summary Get the comment moderation queue
+// @Description List comments awaiting moderation, or comments in another moderation status
+// @Tags Admin
+// @Produce  json
+// @Security BearerAuth
+// @Param status query string false "Moderation status (pending, approved, rejected, spam)" default(pending)
+// @Success 200 {array} dto.CommentModerationDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Router /api/admin/comments [get]
+func (ctrl *AdminController) GetCommentModerationQueue(c *gin.Context) {
+	comments, err := ctrl.commentService.FindModerationQueue(c.Query("status"))
+	if err != nil {
+		respondCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, comments)
+}
+
+// ModerateComment godoc
+// @Summary Moderate a comment
+// @Description Approve, reject or mark a comment as spam
+// @Tags Admin
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Comment ID"
+// @Param moderation body dto.CommentModerationRequestDto true "Moderation decision"
+// @Success 200 {object} handler.SuccessResponse
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/admin/comments/{id}/status [put]
+func (ctrl *AdminController) ModerateComment(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: "Invalid comment ID"})
+		return
+	}
+
+	var moderationRequestDto dto.CommentModerationRequestDto
+	if err := c.ShouldBindJSON(&moderationRequestDto); err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Invalid input", Message: "Failed to parse moderation data"})
+		return
+	}
+
+	if err := ctrl.commentService.ModerateComment(uint(id), moderationRequestDto); err != nil {
+		respondCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handler.SuccessResponse{Message: "Comment moderated successfully"})
+}
+
+// GetCommentModerationRule godoc
+// @Summary Get an author's comment moderation rule
+// @Tags Admin
+// @Produce  json
+// @Security BearerAuth
+// @Param username path string true "Author Name"
+// @Success 200 {object} dto.CommentModerationRuleDto
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/admin/authors/{username}/comment-rule [get]
+func (ctrl *AdminController) GetCommentModerationRule(c *gin.Context) {
+	rule, err := ctrl.commentService.GetModerationRule(c.Param("username"))
+	if err != nil {
+		respondCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, rule)
+}
+
+// SaveCommentModerationRule godoc
+// @Summary Set an author's comment moderation rule
+// @Description Choose whether comments on the author's blogs are auto-approved, auto-approved for trusted commenters, or always held for review
+// @Tags Admin
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param username path string true "Author Name"
+// @Param rule body dto.CommentModerationRuleDto true "Moderation rule"
+// @Success 200 {object} dto.CommentModerationRuleDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/admin/authors/{username}/comment-rule [put]
+func (ctrl *AdminController) SaveCommentModerationRule(c *gin.Context) {
+	var ruleDto dto.CommentModerationRuleDto
+	if err := c.ShouldBindJSON(&ruleDto); err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Invalid input", Message: "Failed to parse moderation rule"})
+		return
+	}
+
+	rule, err := ctrl.commentService.SaveModerationRule(c.Param("username"), ruleDto)
+	if err != nil {
+		respondCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, rule)
+}
@@ -0,0 +1,330 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+	"strconv"
+	"yp-blog-api/internal/dto"
+	"yp-blog-api/internal/handler"
+	"yp-blog-api/internal/service"
+)
+
+// AdvertisingBannerController serves banners to readers and lets administrators manage them
+type AdvertisingBannerController struct {
+	bannerService service.AdvertisingBannerService
+}
+
+// NewAdvertisingBannerController creates a new AdvertisingBannerController
+func NewAdvertisingBannerController(bannerService service.AdvertisingBannerService) *AdvertisingBannerController {
+	return &AdvertisingBannerController{
+		bannerService: bannerService,
+	}
+}
+
+// GetBanners godoc
+// @Summary List banners
+// @Description List the advertising banners shown to readers
+// @Tags Banner
+// @Produce  json
+// @Success 200 {array} dto.AdvertisingBannerDto
+// @Router /api/banners [get]
+func (ctrl *AdvertisingBannerController) GetBanners(c *gin.Context) {
+	banners, err := ctrl.bannerService.GetAllBanners()
+	if err != nil {
+		respondBannerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, banners)
+}
+
+// GetBanner godoc
+// @Summary Get a banner
+// @Tags Banner
+// @Produce  json
+// @Param id path int true "Banner ID"
+// @Success 200 {object} dto.AdvertisingBannerDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/banners/{id} [get]
+func (ctrl *AdvertisingBannerController) GetBanner(c *gin.Context) {
+	id, ok := bannerIdParam(c)
+	if !ok {
+		return
+	}
+	banner, err := ctrl.bannerService.GetBannerById(id)
+	if err != nil {
+		respondBannerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, banner)
+}
+
+// GetBannersForAdmin godoc
+// @Summary List banners for admin
+// @Tags Banner
+// @Produce  json
+// @Security BearerAuth
+// @Param includeDeleted query bool false "Include deleted banners" default(false)
+// @Success 200 {array} dto.AdvertisingBannerAdminDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Router /api/admin/banners [get]
+func (ctrl *AdvertisingBannerController) GetBannersForAdmin(c *gin.Context) {
+	includeDeleted, err := strconv.ParseBool(c.DefaultQuery("includeDeleted", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: "Invalid includeDeleted"})
+		return
+	}
+	banners, err := ctrl.bannerService.GetAllBannersForAdmin(includeDeleted)
+	if err != nil {
+		respondBannerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, banners)
+}
+
+// GetBannerForAdmin godoc
+// @Summary Get a banner for admin
+// @Description Get a banner, deleted or not
+// @Tags Banner
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Banner ID"
+// @Success 200 {object} dto.AdvertisingBannerAdminDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/admin/banners/{id} [get]
+func (ctrl *AdvertisingBannerController) GetBannerForAdmin(c *gin.Context) {
+	id, ok := bannerIdParam(c)
+	if !ok {
+		return
+	}
+	banner, err := ctrl.bannerService.GetBannerByIdForAdmin(id)
+	if err != nil {
+		respondBannerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, banner)
+}
+
+// CreateBanner godoc
+// @Summary Create a banner
+// @Description The image URL is an http(s) URL or a path on this site, such as an uploaded image; the link is an http(s) URL.
+// @Description The campaign runs from startsAt to endsAt, RFC 3339 times or local times such as 2024-06-01T09:00 in the timezone.
+// @Description Active banners are picked by priority weight until their daily impression cap, reset at midnight in the timezone.
+// @Description Banners targeted at categories are only shown on the listings of those categories.
+// @Tags Banner
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param banner body dto.AdvertisingBannerRequestDto true "Banner"
+// @Success 201 {object} dto.AdvertisingBannerAdminDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Router /api/admin/banners [post]
+func (ctrl *AdvertisingBannerController) CreateBanner(c *gin.Context) {
+	var bannerDto dto.AdvertisingBannerRequestDto
+	if err := c.ShouldBindJSON(&bannerDto); err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Invalid input", Message: "Failed to parse banner data"})
+		return
+	}
+	banner, err := ctrl.bannerService.CreateBanner(bannerDto)
+	if err != nil {
+		respondBannerError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, banner)
+}
+
+// UpdateBanner godoc
+// @Summary Update a banner
+// @Tags Banner
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Banner ID"
+// @Param banner body dto.AdvertisingBannerRequestDto true "Banner"
+// @Success 200 {object} dto.AdvertisingBannerAdminDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/admin/banners/{id} [put]
+func (ctrl *AdvertisingBannerController) UpdateBanner(c *gin.Context) {
+	id, ok := bannerIdParam(c)
+	if !ok {
+		return
+	}
+	var bannerDto dto.AdvertisingBannerRequestDto
+	if err := c.ShouldBindJSON(&bannerDto); err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Invalid input", Message: "Failed to parse banner data"})
+		return
+	}
+	banner, err := ctrl.bannerService.UpdateBanner(id, bannerDto)
+	if err != nil {
+		respondBannerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, banner)
+}
+
+// DeleteBanner godoc
+// @Summary Delete a banner
+// @Description Hide a banner from readers. It is kept and can be restored.
+// @Tags Banner
+// @Security BearerAuth
+// @Param id path int true "Banner ID"
+// @Success 204
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/admin/banners/{id} [delete]
+func (ctrl *AdvertisingBannerController) DeleteBanner(c *gin.Context) {
+	id, ok := bannerIdParam(c)
+	if !ok {
+		return
+	}
+	if err := ctrl.bannerService.DeleteBanner(id); err != nil {
+		respondBannerError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// RestoreBanner godoc
+// @Summary Restore a deleted banner
+// @Tags Banner
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Banner ID"
+// @Success 200 {object} dto.AdvertisingBannerAdminDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/admin/banners/{id}/restore [post]
+func (ctrl *AdvertisingBannerController) RestoreBanner(c *gin.Context) {
+	id, ok := bannerIdParam(c)
+	if !ok {
+		return
+	}
+	banner, err := ctrl.bannerService.RestoreBanner(id)
+	if err != nil {
+		respondBannerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, banner)
+}
+
+// RedirectBanner godoc
+// @Summary Follow a banner link
+// @Description Redirect to the link of a banner, counting the click while the banner is shown. Banners served to readers link here.
+// @Tags Banner
+// @Param id path int true "Banner ID"
+// @Success 302
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /r/banner/{id} [get]
+func (ctrl *AdvertisingBannerController) RedirectBanner(c *gin.Context) {
+	id, ok := bannerIdParam(c)
+	if !ok {
+		return
+	}
+	link, err := ctrl.bannerService.RecordClick(id)
+	if err != nil {
+		respondBannerError(c, err)
+		return
+	}
+	// Every click must reach the server to be counted
+	c.Header("Cache-Control", "no-store")
+	c.Redirect(http.StatusFound, link)
+}
+
+// GetBannerReports godoc
+// @Summary Report banner performance
+// @Description Total impressions, clicks and click-through rate of every banner over a range of days, by default the last 30
+// @Tags Banner
+// @Produce  json
+// @Security BearerAuth
+// @Param from query string false "First day, e.g. 2024-05-01"
+// @Param to query string false "Last day, e.g. 2024-05-31; defaults to today"
+// @Success 200 {array} dto.BannerReportDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Router /api/admin/banners/report [get]
+func (ctrl *AdvertisingBannerController) GetBannerReports(c *gin.Context) {
+	reports, err := ctrl.bannerService.GetBannerReports(c.Query("from"), c.Query("to"))
+	if err != nil {
+		respondBannerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, reports)
+}
+
+// GetBannerReport godoc
+// @Summary Report a banner's performance by day
+// @Description Impressions, clicks and click-through rate of a banner for every day of a range, by default the last 30.
+// @Description Days are in the banner's time zone.
+// @Tags Banner
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Banner ID"
+// @Param from query string false "First day, e.g. 2024-05-01"
+// @Param to query string false "Last day, e.g. 2024-05-31; defaults to today"
+// @Success 200 {object} dto.BannerReportDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/admin/banners/{id}/report [get]
+func (ctrl *AdvertisingBannerController) GetBannerReport(c *gin.Context) {
+	id, ok := bannerIdParam(c)
+	if !ok {
+		return
+	}
+	report, err := ctrl.bannerService.GetBannerReport(id, c.Query("from"), c.Query("to"))
+	if err != nil {
+		respondBannerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// bannerIdParam parses the banner id path parameter, responding with 400 when it is invalid
+func bannerIdParam(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: "Invalid banner ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+// respondBannerError maps banner service errors to HTTP responses
+func respondBannerError(c *gin.Context, err error) {
+	var validationErrors validator.ValidationErrors
+	switch {
+	case errors.As(err, &validationErrors):
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{
+			Error:   "Validation Failed",
+			Message: "Some fields did not pass validation",
+			Fields:  handler.FormatValidationErrors(validationErrors),
+		})
+	case errors.Is(err, service.ErrInvalidBannerImageURL), errors.Is(err, service.ErrInvalidBannerLink),
+		errors.Is(err, service.ErrInvalidBannerTimezone), errors.Is(err, service.ErrInvalidBannerSchedule),
+		errors.Is(err, service.ErrInvalidReportRange), errors.Is(err, service.ErrUnknownBannerCategory):
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: err.Error()})
+	case errors.Is(err, service.ErrBannerNotFound):
+		c.JSON(http.StatusNotFound, handler.ErrorResponse{Error: "Not Found", Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, handler.ErrorResponse{Error: "Internal Server Error", Message: err.Error()})
+	}
+}
@@ -1,14 +1,53 @@
 package controller
 
-import "yp-blog-api/internal/service"
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"yp-blog-api/internal/handler"
+	"yp-blog-api/internal/middleware"
+	"yp-blog-api/internal/service"
+)
 
 type AuthorController struct {
-	blogService service.BlogService
+	blogService   service.BlogService
+	followService service.FollowService
 }
 
-// NewAuthorController NewBlogController creates a new BlogController
-func NewAuthorController(blogService service.BlogService) *AuthorController {
+// NewAuthorController creates a new AuthorController
+func NewAuthorController(blogService service.BlogService, followService service.FollowService) *AuthorController {
 	return &AuthorController{
-		blogService: blogService,
+		blogService:   blogService,
+		followService: followService,
+	}
+}
+
+// GetAuthorProfile godoc
+// @Summary Get an author profile
+// @Description Get an author's profile with follower counts, and whether the signed-in reader follows them
+// @Tags Author
+// @Produce  json
+// @Param username path string true "Author Name"
+// @Success 200 {object} dto.AuthorCardDetailDto
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/authors/{username} [get]
+func (ctrl *AuthorController) GetAuthorProfile(c *gin.Context) {
+	profile, err := ctrl.followService.FindAuthorProfile(c.Param("username"), middleware.CurrentUserID(c))
+	if err != nil {
+		respondFollowError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, profile)
+}
+
+// respondFollowError maps follow service errors to HTTP responses
+func respondFollowError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrCannotFollowSelf), errors.Is(err, service.ErrInvalidFeedCursor):
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: err.Error()})
+	case errors.Is(err, service.ErrUserNotFound), errors.Is(err, service.ErrTagNotFound):
+		c.JSON(http.StatusNotFound, handler.ErrorResponse{Error: "Not Found", Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, handler.ErrorResponse{Error: "Internal Server Error", Message: err.Error()})
 	}
 }
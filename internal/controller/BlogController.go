@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"yp-blog-api/internal/dto"
 	"yp-blog-api/internal/handler"
+	"yp-blog-api/internal/middleware"
 	"yp-blog-api/internal/models"
 	"yp-blog-api/internal/service"
 )
@@ -68,10 +69,11 @@ func (ctrl *BlogController) GetBlogById(c *gin.Context) {
 
 // CreateBlogAdmin handles POST requests to create a new blog
 // @Summary Create a new blog
-// @Description Create a new blog with the provided details
+// @Description Create a new blog with the provided details. When signed in, the caller becomes its author.
 // @Tags Blog
 // @Accept  json
 // @Produce  json
+// @Security BearerAuth
 // @Param blog body models.Blog true "Blog data"
 // @Success 200 {object} models.Blog
 // @Failure 400 {object} handler.ErrorResponse
@@ -97,17 +99,23 @@ func (ctrl *BlogController) CreateBlogAdmin(c *gin.Context) {
 	c.JSON(http.StatusOK, savedBlog)
 }
 
-// UpdateBlog handles PUT requests to update an existing blog by its slug
+// UpdateBlog handles PUT requests to update an existing blog by its author and slug
 // @Summary Update an existing blog
-// @Description Update a blog by its slug
+// @Description Update a blog by its author and slug
 // @Tags Blog
 // @Accept  json
 // @Produce  json
+// @Param author path string true "Author Name"
 // @Param slug path string true "Blog Slug"
 // @Param blog body dto.BlogUpdateRequestDto true "Blog update data"
-// @Router /api/blogs/{slug} [put]
+// @Success 200 {object} handler.SuccessResponse
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Failure 409 {object} handler.ErrorResponse
+// @Router /api/blogs/:author/:slug [put]
 func (ctrl *BlogController) UpdateBlog(ctx *gin.Context) {
 	var blogUpdateRequestDto dto.BlogUpdateRequestDto
+	author := ctx.Param("author")
 	slug := ctx.Param("slug")
 
 	// Bind the request body to the DTO
@@ -132,7 +140,7 @@ func (ctrl *BlogController) UpdateBlog(ctx *gin.Context) {
 	}
 
 	// Call the service to update the blog
-	if err := ctrl.blogService.UpdateBlog(blogUpdateRequestDto, slug); err != nil {
+	if err := ctrl.blogService.UpdateBlog(blogUpdateRequestDto, author, slug); err != nil {
 		if err.Error() == "blog not found" {
 			ctx.JSON(http.StatusNotFound, handler.ErrorResponse{
 				Error:   "Not Found",
@@ -140,6 +148,9 @@ func (ctrl *BlogController) UpdateBlog(ctx *gin.Context) {
 			})
 			return
 		}
+		if respondSlugError(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, handler.ErrorResponse{
 			Error:   "Internal Server Error",
 			Message: err.Error(),
@@ -182,6 +193,7 @@ func (ctrl *BlogController) DeleteBlog(c *gin.Context) {
 // @Tags Blog
 // @Produce  json
 // @Param categoriesSlug path string true "Category Slug"
+// @Param sort query string false "Ordering; use most-liked to order by reactions"
 // @Success 200 {array} models.Blog
 // @Router /api/blogs/{categoriesSlug} [get]
 // @Router /api/blogs/ [get]
@@ -190,7 +202,7 @@ func (ctrl *BlogController) ListAllByCategoriesSlug(c *gin.Context) {
 	slug := c.Param("categoriesSlug")
 
 	// Call the service to get the list of blog cards
-	blogCards := ctrl.blogService.FindBlogCardByCategoriesSlug(slug)
+	blogCards := ctrl.blogService.FindBlogCardByCategoriesSlug(slug, c.Query("sort"), middleware.CurrentUserID(c))
 
 	// Respond with the result in JSON format
 	c.JSON(http.StatusOK, blogCards)
@@ -205,6 +217,7 @@ func (ctrl *BlogController) ListAllByCategoriesSlug(c *gin.Context) {
 // @Param blog body dto.BlogCreateRequestDto true "Blog data"
 // @Success 200 {object} handler.SuccessResponse
 // @Failure 400 {object} handler.ErrorResponse
+// @Failure 409 {object} handler.ErrorResponse
 // @Failure 500 {object} handler.ErrorResponse
 // @Router /api/blogs [post]
 func (ctrl *BlogController) CreateBlog(c *gin.Context) {
@@ -229,7 +242,10 @@ func (ctrl *BlogController) CreateBlog(c *gin.Context) {
 	}
 
 	// Call the service layer to create the blog
-	if err := ctrl.blogService.CreateBlog(blogCreateRequestDto); err != nil {
+	if err := ctrl.blogService.CreateBlog(blogCreateRequestDto, middleware.CurrentUserID(c)); err != nil {
+		if respondSlugError(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, handler.ErrorResponse{
 			Error:   "Internal Server Error",
 			Message: err.Error(),
@@ -249,6 +265,7 @@ func (ctrl *BlogController) CreateBlog(c *gin.Context) {
 // @Param author path string true "Author Name"
 // @Param slug path string true "Blog Slug"
 // @Success 200 {object} dto.BlogDetailDto
+// @Success 301 "Blog moved; the Location header holds the canonical @author/slug URL"
 // @Failure 404 {object} handler.ErrorResponse
 func (ctrl *BlogController) GetBlogDetailByAuthorAndSlug(c *gin.Context) {
 	// Extract the 'author' and 'slug' parameters from the URL
@@ -256,8 +273,14 @@ func (ctrl *BlogController) GetBlogDetailByAuthorAndSlug(c *gin.Context) {
 	slug := c.Param("slug")
 
 	// Call the service method to find the blog detail
-	blogDetail, err := ctrl.blogService.FindBlogDetailByAuthorAndSlug(author, slug)
+	blogDetail, err := ctrl.blogService.FindBlogDetailByAuthorAndSlug(author, slug, middleware.CurrentUserID(c))
 	if err != nil {
+		// Redirect permanently when the blog was requested by an old author name or slug
+		var moved *service.BlogMovedError
+		if errors.As(err, &moved) {
+			c.Redirect(http.StatusMovedPermanently, moved.Location())
+			return
+		}
 		// Handle the error, respond with 404 if the blog is not found
 		c.JSON(http.StatusNotFound, handler.ErrorResponse{Error: "Not Found", Message: "Blog not found"})
 		return
@@ -267,6 +290,29 @@ func (ctrl *BlogController) GetBlogDetailByAuthorAndSlug(c *gin.Context) {
 	c.JSON(http.StatusOK, blogDetail)
 }
 
+// GetRelatedBlogs godoc
+// @Summary Get posts related to a blog
+// @Description Rank published posts by shared tags and categories, title similarity and recency.
+// @Tags Blog
+// @Param author path string true "Author Name"
+// @Param slug path string true "Blog Slug"
+// @Produce  json
+// @Success 200 {array} dto.BlogCardDto
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/blogs/:author/:slug/related [get]
+func (ctrl *BlogController) GetRelatedBlogs(c *gin.Context) {
+	author := c.Param("author")
+	slug := c.Param("slug")
+
+	blogCardDtos, err := ctrl.blogService.FindRelatedBlogs(author, slug, middleware.CurrentUserID(c))
+	if err != nil {
+		c.JSON(http.StatusNotFound, handler.ErrorResponse{Error: "Not Found", Message: "Blog not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, blogCardDtos)
+}
+
 // GetRecentPosts handles GET requests to fetch recent blog posts
 // @Summary blog recent post
 // @Description Get the most recent and popular blog posts
@@ -310,7 +356,7 @@ func (ctrl *BlogController) Find6BlogsByCategoriesSlug(c *gin.Context) {
 		return
 	}
 
-	blogCardDtos := ctrl.blogService.Find6BlogsByCategoriesSlug(slug)
+	blogCardDtos := ctrl.blogService.Find6BlogsByCategoriesSlug(slug, middleware.CurrentUserID(c))
 	if len(blogCardDtos) == 0 {
 		c.JSON(http.StatusNoContent, gin.H{"message": "No blogs found"})
 		return
@@ -334,7 +380,7 @@ func (ctrl *BlogController) Find6BlogsByUsernameAndCountViewer(c *gin.Context) {
 		return
 	}
 
-	blogCardDtos := ctrl.blogService.Find6BlogsByUsernameAndCountViewer(username)
+	blogCardDtos := ctrl.blogService.Find6BlogsByUsernameAndCountViewer(username, middleware.CurrentUserID(c))
 	if len(blogCardDtos) == 0 {
 		c.JSON(http.StatusNoContent, gin.H{"message": "No blogs found"})
 		return
@@ -371,3 +417,16 @@ func (ctrl *BlogController) DeleteBlogByChangeStatus(c *gin.Context) {
 	// Respond with success message
 	c.JSON(http.StatusOK, "Blog marked as deleted")
 }
+
+// respondSlugError writes a 400 or 409 response for slug errors and reports whether it did
+func respondSlugError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, service.ErrInvalidSlug):
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Invalid Slug", Message: err.Error()})
+	case errors.Is(err, service.ErrSlugConflict):
+		c.JSON(http.StatusConflict, handler.ErrorResponse{Error: "Conflict", Message: err.Error()})
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,352 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+	"strconv"
+	"yp-blog-api/internal/dto"
+	"yp-blog-api/internal/handler"
+	"yp-blog-api/internal/middleware"
+	"yp-blog-api/internal/service"
+)
+
+// BookmarkController serves the signed-in reader's bookmarks and reading lists.
+// Its routes are expected to sit behind middleware.RequireUser.
+type BookmarkController struct {
+	bookmarkService service.BookmarkService
+}
+
+// NewBookmarkController creates a new BookmarkController
+func NewBookmarkController(bookmarkService service.BookmarkService) *BookmarkController {
+	return &BookmarkController{
+		bookmarkService: bookmarkService,
+	}
+}
+
+// GetBookmarks godoc
+// @Summary List bookmarked blogs
+// @Description List the blogs the signed-in reader bookmarked, in bookmark order
+// @Tags Bookmark
+// @Produce  json
+// @Security BearerAuth
+// @Success 200 {array} dto.BlogCardDto
+// @Failure 401 {object} handler.ErrorResponse
+// @Router /api/me/bookmarks [get]
+func (ctrl *BookmarkController) GetBookmarks(c *gin.Context) {
+	blogCardDtos, err := ctrl.bookmarkService.FindBookmarks(middleware.CurrentUser(c).ID)
+	if err != nil {
+		respondBookmarkError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, blogCardDtos)
+}
+
+// AddBookmark godoc
+// @Summary Bookmark a blog
+// @Description Bookmark a blog. Bookmarking it again has no further effect.
+// @Tags Bookmark
+// @Produce  json
+// @Security BearerAuth
+// @Param author path string true "Author Name"
+// @Param slug path string true "Blog Slug"
+// @Success 200 {object} handler.SuccessResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/me/bookmarks/:author/:slug [put]
+func (ctrl *BookmarkController) AddBookmark(c *gin.Context) {
+	if err := ctrl.bookmarkService.AddBookmark(middleware.CurrentUser(c).ID, c.Param("author"), c.Param("slug")); err != nil {
+		respondBookmarkError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handler.SuccessResponse{Message: "Blog bookmarked"})
+}
+
+// RemoveBookmark godoc
+// @Summary Remove a bookmark
+// @Description Remove a bookmark. Removing a blog that is not bookmarked has no effect.
+// @Tags Bookmark
+// @Produce  json
+// @Security BearerAuth
+// @Param author path string true "Author Name"
+// @Param slug path string true "Blog Slug"
+// @Success 200 {object} handler.SuccessResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/me/bookmarks/:author/:slug [delete]
+func (ctrl *BookmarkController) RemoveBookmark(c *gin.Context) {
+	if err := ctrl.bookmarkService.RemoveBookmark(middleware.CurrentUser(c).ID, c.Param("author"), c.Param("slug")); err != nil {
+		respondBookmarkError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handler.SuccessResponse{Message: "Bookmark removed"})
+}
+
+// ReorderBookmarks godoc
+// @Summary Reorder bookmarks
+// @Description Move the listed blogs to the front of the bookmarks in the given order
+// @Tags Bookmark
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param order body dto.BlogOrderRequestDto true "Blogs in their new order"
+// @Success 200 {array} dto.BlogCardDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/me/bookmarks/order [put]
+func (ctrl *BookmarkController) ReorderBookmarks(c *gin.Context) {
+	var orderDto dto.BlogOrderRequestDto
+	if err := c.ShouldBindJSON(&orderDto); err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Invalid input", Message: "Failed to parse order data"})
+		return
+	}
+
+	blogCardDtos, err := ctrl.bookmarkService.ReorderBookmarks(middleware.CurrentUser(c).ID, orderDto)
+	if err != nil {
+		respondBookmarkError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, blogCardDtos)
+}
+
+// GetReadingLists godoc
+// @Summary List reading lists
+// @Description List the signed-in reader's reading lists by name
+// @Tags Bookmark
+// @Produce  json
+// @Security BearerAuth
+// @Success 200 {array} dto.ReadingListDto
+// @Failure 401 {object} handler.ErrorResponse
+// @Router /api/me/reading-lists [get]
+func (ctrl *BookmarkController) GetReadingLists(c *gin.Context) {
+	readingLists, err := ctrl.bookmarkService.FindReadingLists(middleware.CurrentUser(c).ID)
+	if err != nil {
+		respondBookmarkError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, readingLists)
+}
+
+// CreateReadingList godoc
+// @Summary Create a reading list
+// @Description Create a named reading list; names are unique per reader
+// @Tags Bookmark
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param readingList body dto.ReadingListRequestDto true "Reading list data"
+// @Success 201 {object} dto.ReadingListDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 409 {object} handler.ErrorResponse
+// @Router /api/me/reading-lists [post]
+func (ctrl *BookmarkController) CreateReadingList(c *gin.Context) {
+	var requestDto dto.ReadingListRequestDto
+	if err := c.ShouldBindJSON(&requestDto); err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Invalid input", Message: "Failed to parse reading list data"})
+		return
+	}
+
+	readingList, err := ctrl.bookmarkService.CreateReadingList(middleware.CurrentUser(c).ID, requestDto)
+	if err != nil {
+		respondBookmarkError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, readingList)
+}
+
+// GetReadingList godoc
+// @Summary Get a reading list
+// @Description Get a reading list with its blogs in list order
+// @Tags Bookmark
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Reading list ID"
+// @Success 200 {object} dto.ReadingListDetailDto
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/me/reading-lists/{id} [get]
+func (ctrl *BookmarkController) GetReadingList(c *gin.Context) {
+	id, ok := readingListIdParam(c)
+	if !ok {
+		return
+	}
+	readingList, err := ctrl.bookmarkService.FindReadingList(middleware.CurrentUser(c).ID, id)
+	if err != nil {
+		respondBookmarkError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, readingList)
+}
+
+// RenameReadingList godoc
+// @Summary Rename a reading list
+// @Tags Bookmark
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Reading list ID"
+// @Param readingList body dto.ReadingListRequestDto true "Reading list data"
+// @Success 200 {object} dto.ReadingListDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Failure 409 {object} handler.ErrorResponse
+// @Router /api/me/reading-lists/{id} [put]
+func (ctrl *BookmarkController) RenameReadingList(c *gin.Context) {
+	id, ok := readingListIdParam(c)
+	if !ok {
+		return
+	}
+	var requestDto dto.ReadingListRequestDto
+	if err := c.ShouldBindJSON(&requestDto); err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Invalid input", Message: "Failed to parse reading list data"})
+		return
+	}
+
+	readingList, err := ctrl.bookmarkService.RenameReadingList(middleware.CurrentUser(c).ID, id, requestDto)
+	if err != nil {
+		respondBookmarkError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, readingList)
+}
+
+// DeleteReadingList godoc
+// @Summary Delete a reading list
+// @Description Delete a reading list; the blogs in it stay bookmarked if they were
+// @Tags Bookmark
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Reading list ID"
+// @Success 200 {object} handler.SuccessResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/me/reading-lists/{id} [delete]
+func (ctrl *BookmarkController) DeleteReadingList(c *gin.Context) {
+	id, ok := readingListIdParam(c)
+	if !ok {
+		return
+	}
+	if err := ctrl.bookmarkService.DeleteReadingList(middleware.CurrentUser(c).ID, id); err != nil {
+		respondBookmarkError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handler.SuccessResponse{Message: "Reading list deleted"})
+}
+
+// AddToReadingList godoc
+// @Summary Add a blog to a reading list
+// @Description Append a blog to a reading list. Adding it again has no further effect.
+// @Tags Bookmark
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Reading list ID"
+// @Param author path string true "Author Name"
+// @Param slug path string true "Blog Slug"
+// @Success 200 {object} handler.SuccessResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/me/reading-lists/{id}/blogs/:author/:slug [put]
+func (ctrl *BookmarkController) AddToReadingList(c *gin.Context) {
+	id, ok := readingListIdParam(c)
+	if !ok {
+		return
+	}
+	if err := ctrl.bookmarkService.AddToReadingList(middleware.CurrentUser(c).ID, id, c.Param("author"), c.Param("slug")); err != nil {
+		respondBookmarkError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handler.SuccessResponse{Message: "Blog added to reading list"})
+}
+
+// RemoveFromReadingList godoc
+// @Summary Remove a blog from a reading list
+// @Description Remove a blog from a reading list. Removing a blog that is not in the list has no effect.
+// @Tags Bookmark
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Reading list ID"
+// @Param author path string true "Author Name"
+// @Param slug path string true "Blog Slug"
+// @Success 200 {object} handler.SuccessResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/me/reading-lists/{id}/blogs/:author/:slug [delete]
+func (ctrl *BookmarkController) RemoveFromReadingList(c *gin.Context) {
+	id, ok := readingListIdParam(c)
+	if !ok {
+		return
+	}
+	if err := ctrl.bookmarkService.RemoveFromReadingList(middleware.CurrentUser(c).ID, id, c.Param("author"), c.Param("slug")); err != nil {
+		respondBookmarkError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handler.SuccessResponse{Message: "Blog removed from reading list"})
+}
+
+// ReorderReadingList godoc
+// @Summary Reorder a reading list
+// @Description Move the listed blogs to the front of the reading list in the given order
+// @Tags Bookmark
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Reading list ID"
+// @Param order body dto.BlogOrderRequestDto true "Blogs in their new order"
+// @Success 200 {object} dto.ReadingListDetailDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/me/reading-lists/{id}/order [put]
+func (ctrl *BookmarkController) ReorderReadingList(c *gin.Context) {
+	id, ok := readingListIdParam(c)
+	if !ok {
+		return
+	}
+	var orderDto dto.BlogOrderRequestDto
+	if err := c.ShouldBindJSON(&orderDto); err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Invalid input", Message: "Failed to parse order data"})
+		return
+	}
+
+	readingList, err := ctrl.bookmarkService.ReorderReadingList(middleware.CurrentUser(c).ID, id, orderDto)
+	if err != nil {
+		respondBookmarkError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, readingList)
+}
+
+// readingListIdParam parses the reading list id path parameter, responding with 400 when it is invalid
+func readingListIdParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: "Invalid reading list ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// respondBookmarkError maps bookmark service errors to HTTP responses
+func respondBookmarkError(c *gin.Context, err error) {
+	var validationErrors validator.ValidationErrors
+	switch {
+	case errors.As(err, &validationErrors):
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{
+			Error:   "Validation Failed",
+			Message: "Some fields did not pass validation",
+			Fields:  handler.FormatValidationErrors(validationErrors),
+		})
+	case errors.Is(err, service.ErrReadingListNameTaken):
+		c.JSON(http.StatusConflict, handler.ErrorResponse{Error: "Conflict", Message: err.Error()})
+	case errors.Is(err, service.ErrBlogNotFound):
+		c.JSON(http.StatusNotFound, handler.ErrorResponse{Error: "Not Found", Message: "Blog not found"})
+	case errors.Is(err, service.ErrReadingListNotFound):
+		c.JSON(http.StatusNotFound, handler.ErrorResponse{Error: "Not Found", Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, handler.ErrorResponse{Error: "Internal Server Error", Message: err.Error()})
+	}
+}
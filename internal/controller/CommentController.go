@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+	"strconv"
+	"yp-blog-api/internal/dto"
+	"yp-blog-api/internal/handler"
+	"yp-blog-api/internal/middleware"
+	"yp-blog-api/internal/service"
+)
+
+// commentTokenHeader carries the edit token returned when a comment is created
+const commentTokenHeader = "X-Comment-Token"
+
+type CommentController struct {
+	commentService service.CommentService
+}
+
+// NewCommentController creates a new CommentController
+func NewCommentController(commentService service.CommentService) *CommentController {
+	return &CommentController{
+		commentService: commentService,
+	}
+}
+
+// ListComments godoc
+// @Summary List the comments of a blog
+// @Description Retrieve the approved comments of a blog as a thread of nested replies
+// @Tags Comment
+// @Produce  json
+// @Param author path string true "Author Name"
+// @Param slug path string true "Blog Slug"
+// @Success 200 {array} dto.CommentDto
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/blogs/:author/:slug/comments [get]
+func (ctrl *CommentController) ListComments(c *gin.Context) {
+	comments, err := ctrl.commentService.FindThreadByAuthorAndSlug(c.Param("author"), c.Param("slug"))
+	if err != nil {
+		respondCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, comments)
+}
+
+// CreateComment godoc
+// @Summary Post a comment
+// @Description Post a comment or a reply to another comment. Signed-in readers comment under their user name,
+// @Description guests under the guestName they give. Suspicious comments are held for moderation.
+// @Description The returned editToken is needed to edit or delete the comment.
+// @Tags Comment
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param author path string true "Author Name"
+// @Param slug path string true "Blog Slug"
+// @Param comment body dto.CommentCreateRequestDto true "Comment data"
+// @Success 201 {object} dto.CommentDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/blogs/:author/:slug/comments [post]
+func (ctrl *CommentController) CreateComment(c *gin.Context) {
+	var commentCreateRequestDto dto.CommentCreateRequestDto
+	if err := c.ShouldBindJSON(&commentCreateRequestDto); err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Invalid input", Message: "Failed to parse comment data"})
+		return
+	}
+
+	comment, err := ctrl.commentService.CreateComment(c.Param("author"), c.Param("slug"), middleware.CurrentUser(c), c.ClientIP(), commentCreateRequestDto)
+	if err != nil {
+		respondCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, comment)
+}
+
+// UpdateComment godoc
+// @Summary Edit a comment
+// @Description Edit the body of a comment within 15 minutes of posting it
+// @Tags Comment
+// @Accept  json
+// @Produce  json
+// @Param author path string true "Author Name"
+// @Param slug path string true "Blog Slug"
+// @Param id path int true "Comment ID"
+// @Param X-Comment-Token header string true "Edit token returned when the comment was created"
+// @Param comment body dto.CommentUpdateRequestDto true "Comment data"
+// @Success 200 {object} dto.CommentDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/blogs/:author/:slug/comments/{id} [put]
+func (ctrl *CommentController) UpdateComment(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: "Invalid comment ID"})
+		return
+	}
+
+	var commentUpdateRequestDto dto.CommentUpdateRequestDto
+	if err := c.ShouldBindJSON(&commentUpdateRequestDto); err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Invalid input", Message: "Failed to parse comment data"})
+		return
+	}
+
+	comment, err := ctrl.commentService.UpdateComment(c.Param("author"), c.Param("slug"), uint(id), c.GetHeader(commentTokenHeader), commentUpdateRequestDto)
+	if err != nil {
+		respondCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, comment)
+}
+
+// DeleteComment godoc
+// @Summary Delete a comment
+// @Description Soft delete a comment; replies to it stay in the thread
+// @Tags Comment
+// @Param author path string true "Author Name"
+// @Param slug path string true "Blog Slug"
+// @Param id path int true "Comment ID"
+// @Param X-Comment-Token header string true "Edit token returned when the comment was created"
+// @Success 200 {object} handler.SuccessResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/blogs/:author/:slug/comments/{id} [delete]
+func (ctrl *CommentController) DeleteComment(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: "Invalid comment ID"})
+		return
+	}
+
+	if err := ctrl.commentService.DeleteComment(c.Param("author"), c.Param("slug"), uint(id), c.GetHeader(commentTokenHeader)); err != nil {
+		respondCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handler.SuccessResponse{Message: "Comment deleted successfully"})
+}
+
+// respondCommentError maps comment service errors to HTTP responses
+func respondCommentError(c *gin.Context, err error) {
+	var validationErrors validator.ValidationErrors
+	switch {
+	case errors.As(err, &validationErrors):
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{
+			Error:   "Validation Failed",
+			Message: "Some fields did not pass validation",
+			Fields:  handler.FormatValidationErrors(validationErrors),
+		})
+	case errors.Is(err, service.ErrParentCommentNotFound), errors.Is(err, service.ErrGuestNameRequired):
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: err.Error()})
+	case errors.Is(err, service.ErrCommentForbidden):
+		c.JSON(http.StatusForbidden, handler.ErrorResponse{Error: "Forbidden", Message: err.Error()})
+	case errors.Is(err, service.ErrCommentEditWindowExpired):
+		c.JSON(http.StatusForbidden, handler.ErrorResponse{Error: "Forbidden", Message: err.Error()})
+	case errors.Is(err, service.ErrBlogNotFound):
+		c.JSON(http.StatusNotFound, handler.ErrorResponse{Error: "Not Found", Message: "Blog not found"})
+	case errors.Is(err, service.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, handler.ErrorResponse{Error: "Not Found", Message: err.Error()})
+	case errors.Is(err, service.ErrCommentNotFound):
+		c.JSON(http.StatusNotFound, handler.ErrorResponse{Error: "Not Found", Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, handler.ErrorResponse{Error: "Internal Server Error", Message: err.Error()})
+	}
+}
@@ -0,0 +1,194 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"yp-blog-api/internal/handler"
+	"yp-blog-api/internal/service"
+	"yp-blog-api/internal/syndication"
+)
+
+// feedCacheControl lets readers, crawlers and proxies reuse a feed or a sitemap for a few
+// minutes before revalidating it
+const feedCacheControl = "public, max-age=300"
+
+// privateFeedCacheControl is used instead when links were built from the request's Host
+// and X-Forwarded-Proto headers, so a shared cache cannot serve one client's links to all
+const privateFeedCacheControl = "private, max-age=300"
+
+// FeedController serves the site's posts as RSS, Atom and JSON feeds
+type FeedController struct {
+	syndicationService service.SyndicationService
+}
+
+// NewFeedController creates a new FeedController
+func NewFeedController(syndicationService service.SyndicationService) *FeedController {
+	return &FeedController{
+		syndicationService: syndicationService,
+	}
+}
+
+// GetRSSFeed godoc
+// @Summary RSS feed
+// @Description Latest published posts as RSS 2.0, for the whole site or one category, tag or author.
+// @Description Supports conditional requests with If-None-Match and If-Modified-Since.
+// @Tags Feed
+// @Produce  application/rss+xml
+// @Param category query string false "Category slug"
+// @Param tag query string false "Tag"
+// @Param author query string false "Author username"
+// @Param mode query string false "summary or full" default(summary)
+// @Param limit query int false "Number of posts, at most 100" default(20)
+// @Success 200 {string} string "RSS document"
+// @Success 304
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /feed.xml [get]
+func (ctrl *FeedController) GetRSSFeed(c *gin.Context) {
+	ctrl.serveFeed(c, syndication.ContentTypeRSS, syndication.EncodeRSS)
+}
+
+// GetAtomFeed godoc
+// @Summary Atom feed
+// @Description Latest published posts as Atom 1.0, for the whole site or one category, tag or author.
+// @Description Supports conditional requests with If-None-Match and If-Modified-Since.
+// @Tags Feed
+// @Produce  application/atom+xml
+// @Param category query string false "Category slug"
+// @Param tag query string false "Tag"
+// @Param author query string false "Author username"
+// @Param mode query string false "summary or full" default(summary)
+// @Param limit query int false "Number of posts, at most 100" default(20)
+// @Success 200 {string} string "Atom document"
+// @Success 304
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /atom.xml [get]
+func (ctrl *FeedController) GetAtomFeed(c *gin.Context) {
+	ctrl.serveFeed(c, syndication.ContentTypeAtom, syndication.EncodeAtom)
+}
+
+// GetJSONFeed godoc
+// @Summary JSON feed
+// @Description Latest published posts as JSON Feed 1.1, for the whole site or one category, tag or author.
+// @Description Supports conditional requests with If-None-Match and If-Modified-Since.
+// @Tags Feed
+// @Produce  application/feed+json
+// @Param category query string false "Category slug"
+// @Param tag query string false "Tag"
+// @Param author query string false "Author username"
+// @Param mode query string false "summary or full" default(summary)
+// @Param limit query int false "Number of posts, at most 100" default(20)
+// @Success 200 {string} string "JSON Feed document"
+// @Success 304
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /feed.json [get]
+func (ctrl *FeedController) GetJSONFeed(c *gin.Context) {
+	ctrl.serveFeed(c, syndication.ContentTypeJSONFeed, syndication.EncodeJSONFeed)
+}
+
+// serveFeed builds the requested feed, encodes it and answers conditional requests
+func (ctrl *FeedController) serveFeed(c *gin.Context, contentType string, encode func(syndication.Feed) ([]byte, error)) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: "Invalid limit"})
+		return
+	}
+	query := service.FeedQuery{
+		Category: c.Query("category"),
+		Tag:      c.Query("tag"),
+		Author:   c.Query("author"),
+		Mode:     c.Query("mode"),
+		Limit:    limit,
+	}
+	origin := requestOrigin(c)
+	feed, err := ctrl.syndicationService.BuildFeed(query, origin, origin+c.Request.URL.RequestURI())
+	if err != nil {
+		respondFeedError(c, err)
+		return
+	}
+	body, err := encode(*feed)
+	if err != nil {
+		respondFeedError(c, err)
+		return
+	}
+	writeCacheable(c, contentType, body, feed.Updated, ctrl.syndicationService.HasSiteURL())
+}
+
+// writeCacheable sends a generated document with its validators, or 304 when the
+// client's copy is still current. lastModified may be zero when it is unknown. shared
+// tells whether proxies may cache the document for everyone.
+func writeCacheable(c *gin.Context, contentType string, body []byte, lastModified time.Time, shared bool) {
+	// The ETag covers the whole document, so it also changes when an entry is removed
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	c.Header("ETag", etag)
+	if shared {
+		c.Header("Cache-Control", feedCacheControl)
+	} else {
+		c.Header("Cache-Control", privateFeedCacheControl)
+	}
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	if notModified(c.Request, etag, lastModified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// notModified evaluates If-None-Match and, when it is absent, If-Modified-Since
+func notModified(request *http.Request, etag string, lastModified time.Time) bool {
+	if ifNoneMatch := request.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+	if lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(request.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	// HTTP dates have a resolution of one second
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// requestOrigin returns the scheme and host the request was made to, honouring the
+// X-Forwarded-Proto header set by the proxy in front of the API
+func requestOrigin(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if forwarded := c.GetHeader("X-Forwarded-Proto"); forwarded != "" {
+		scheme = strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+	return scheme + "://" + c.Request.Host
+}
+
+// respondFeedError maps syndication service errors to HTTP responses
+func respondFeedError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrUnknownFeedMode):
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: err.Error()})
+	case errors.Is(err, service.ErrFeedCategoryNotFound), errors.Is(err, service.ErrFeedTagNotFound),
+		errors.Is(err, service.ErrFeedAuthorNotFound):
+		c.JSON(http.StatusNotFound, handler.ErrorResponse{Error: "Not Found", Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, handler.ErrorResponse{Error: "Internal Server Error", Message: err.Error()})
+	}
+}
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"yp-blog-api/internal/handler"
+	"yp-blog-api/internal/middleware"
+	"yp-blog-api/internal/service"
+)
+
+// FollowController serves the signed-in reader's follows and personalized feed.
+// Its routes are expected to sit behind middleware.RequireUser.
+type FollowController struct {
+	followService service.FollowService
+}
+
+// NewFollowController creates a new FollowController
+func NewFollowController(followService service.FollowService) *FollowController {
+	return &FollowController{
+		followService: followService,
+	}
+}
+
+// GetFeed godoc
+// @Summary Get the personalized feed
+// @Description List published blogs from followed authors and tags, newest first. Pass the nextCursor of a page to fetch the next one.
+// @Tags Follow
+// @Produce  json
+// @Security BearerAuth
+// @Param cursor query string false "nextCursor of the previous page"
+// @Param limit query int false "Page size, at most 50" default(20)
+// @Success 200 {object} dto.FeedDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Router /api/feed [get]
+func (ctrl *FollowController) GetFeed(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: "Invalid limit"})
+		return
+	}
+
+	feed, err := ctrl.followService.FindFeed(middleware.CurrentUser(c).ID, c.Query("cursor"), limit)
+	if err != nil {
+		respondFollowError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, feed)
+}
+
+// GetFollowing godoc
+// @Summary List followed authors and tags
+// @Tags Follow
+// @Produce  json
+// @Security BearerAuth
+// @Success 200 {object} dto.FollowingDto
+// @Failure 401 {object} handler.ErrorResponse
+// @Router /api/me/following [get]
+func (ctrl *FollowController) GetFollowing(c *gin.Context) {
+	following, err := ctrl.followService.FindFollowing(middleware.CurrentUser(c).ID)
+	if err != nil {
+		respondFollowError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, following)
+}
+
+// FollowAuthor godoc
+// @Summary Follow an author
+// @Description Follow an author. Following them again has no further effect.
+// @Tags Follow
+// @Produce  json
+// @Security BearerAuth
+// @Param username path string true "Author Name"
+// @Success 200 {object} handler.SuccessResponse
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/me/following/authors/{username} [put]
+func (ctrl *FollowController) FollowAuthor(c *gin.Context) {
+	if err := ctrl.followService.FollowAuthor(middleware.CurrentUser(c).ID, c.Param("username")); err != nil {
+		respondFollowError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handler.SuccessResponse{Message: "Author followed"})
+}
+
+// UnfollowAuthor godoc
+// @Summary Unfollow an author
+// @Description Stop following an author. Unfollowing an author who is not followed has no effect.
+// @Tags Follow
+// @Produce  json
+// @Security BearerAuth
+// @Param username path string true "Author Name"
+// @Success 200 {object} handler.SuccessResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/me/following/authors/{username} [delete]
+func (ctrl *FollowController) UnfollowAuthor(c *gin.Context) {
+	if err := ctrl.followService.UnfollowAuthor(middleware.CurrentUser(c).ID, c.Param("username")); err != nil {
+		respondFollowError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handler.SuccessResponse{Message: "Author unfollowed"})
+}
+
+// FollowTag godoc
+// @Summary Follow a tag
+// @Description Follow a tag. Following it again has no further effect.
+// @Tags Follow
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Tag ID"
+// @Success 200 {object} handler.SuccessResponse
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/me/following/tags/{id} [put]
+func (ctrl *FollowController) FollowTag(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: "Invalid tag ID"})
+		return
+	}
+	if err := ctrl.followService.FollowTag(middleware.CurrentUser(c).ID, uint(id)); err != nil {
+		respondFollowError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handler.SuccessResponse{Message: "Tag followed"})
+}
+
+// UnfollowTag godoc
+// @Summary Unfollow a tag
+// @Description Stop following a tag. Unfollowing a tag that is not followed has no effect.
+// @Tags Follow
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Tag ID"
+// @Success 200 {object} handler.SuccessResponse
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Router /api/me/following/tags/{id} [delete]
+func (ctrl *FollowController) UnfollowTag(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: "Invalid tag ID"})
+		return
+	}
+	if err := ctrl.followService.UnfollowTag(middleware.CurrentUser(c).ID, uint(id)); err != nil {
+		respondFollowError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handler.SuccessResponse{Message: "Tag unfollowed"})
+}
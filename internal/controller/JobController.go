@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"yp-blog-api/internal/handler"
+	"yp-blog-api/internal/service"
+)
+
+// JobController lets administrators inspect background jobs and retry dead ones
+type JobController struct {
+	jobQueue service.JobQueue
+}
+
+// NewJobController creates a new JobController
+func NewJobController(jobQueue service.JobQueue) *JobController {
+	return &JobController{
+		jobQueue: jobQueue,
+	}
+}
+
+// GetJobs godoc
+// @Summary List background jobs
+// @Description List background jobs, newest first
+// @Tags Job
+// @Produce  json
+// @Security BearerAuth
+// @Param status query string false "Job status (queued, running, succeeded, dead)"
+// @Param type query string false "Job type"
+// @Param limit query int false "Page size, at most 200" default(50)
+// @Success 200 {array} dto.JobDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Router /api/admin/jobs [get]
+func (ctrl *JobController) GetJobs(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: "Invalid limit"})
+		return
+	}
+	jobs, err := ctrl.jobQueue.FindJobs(c.Query("status"), c.Query("type"), limit)
+	if err != nil {
+		respondJobError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, jobs)
+}
+
+// GetJob godoc
+// @Summary Get a background job
+// @Tags Job
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Job ID"
+// @Success 200 {object} dto.JobDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/admin/jobs/{id} [get]
+func (ctrl *JobController) GetJob(c *gin.Context) {
+	id, ok := jobIdParam(c)
+	if !ok {
+		return
+	}
+	job, err := ctrl.jobQueue.FindJob(id)
+	if err != nil {
+		respondJobError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
+
+// RetryJob godoc
+// @Summary Retry a dead background job
+// @Description Queue a job that ran out of attempts again, with a fresh set of attempts
+// @Tags Job
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Job ID"
+// @Success 202 {object} dto.JobDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Failure 409 {object} handler.ErrorResponse
+// @Router /api/admin/jobs/{id}/retry [post]
+func (ctrl *JobController) RetryJob(c *gin.Context) {
+	id, ok := jobIdParam(c)
+	if !ok {
+		return
+	}
+	job, err := ctrl.jobQueue.RetryJob(id)
+	if err != nil {
+		respondJobError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, job)
+}
+
+// jobIdParam parses the job id path parameter, responding with 400 when it is invalid
+func jobIdParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: "Invalid job ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// respondJobError maps job queue errors to HTTP responses
+func respondJobError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrUnknownJobStatus):
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: err.Error()})
+	case errors.Is(err, service.ErrJobNotFound):
+		c.JSON(http.StatusNotFound, handler.ErrorResponse{Error: "Not Found", Message: err.Error()})
+	case errors.Is(err, service.ErrJobNotDead):
+		c.JSON(http.StatusConflict, handler.ErrorResponse{Error: "Conflict", Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, handler.ErrorResponse{Error: "Internal Server Error", Message: err.Error()})
+	}
+}
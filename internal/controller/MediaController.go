@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"yp-blog-api/internal/handler"
+	"yp-blog-api/internal/middleware"
+	"yp-blog-api/internal/service"
+)
+
+// mediaMultipartOverhead is the room left for the multipart framing around an upload
+const mediaMultipartOverhead = 64 << 10
+
+// MediaController lets signed-in users upload images and manage their media library
+type MediaController struct {
+	mediaService service.MediaService
+	maxSizeBytes int64
+}
+
+// NewMediaController creates a new MediaController that accepts files of at most maxSizeBytes
+func NewMediaController(mediaService service.MediaService, maxSizeBytes int64) *MediaController {
+	return &MediaController{
+		mediaService: mediaService,
+		maxSizeBytes: maxSizeBytes,
+	}
+}
+
+// UploadMedia godoc
+// @Summary Upload an image
+// @Description Upload a JPEG, PNG, GIF or WebP image to the signed-in user's media library.
+// @Description The type is detected from the file content; use the returned url as a thumbnail, profile image or in blog content.
+// @Description EXIF and other metadata are stripped; scaled down variants are generated in the background.
+// @Tags Media
+// @Accept  multipart/form-data
+// @Produce  json
+// @Security BearerAuth
+// @Param file formData file true "Image"
+// @Success 201 {object} dto.MediaDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 413 {object} handler.ErrorResponse
+// @Failure 415 {object} handler.ErrorResponse
+// @Router /api/me/media [post]
+func (ctrl *MediaController) UploadMedia(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, ctrl.maxSizeBytes+mediaMultipartOverhead)
+	header, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			respondMediaError(c, service.ErrMediaTooLarge)
+			return
+		}
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Invalid input", Message: "Failed to parse the uploaded file"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Invalid input", Message: "Failed to parse the uploaded file"})
+		return
+	}
+	defer file.Close()
+
+	media, err := ctrl.mediaService.Upload(middleware.CurrentUser(c).ID, header.Filename, file)
+	if err != nil {
+		respondMediaError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, media)
+}
+
+// GetMediaLibrary godoc
+// @Summary List the media library
+// @Description List the signed-in user's uploads, newest first, with the blogs using each of them
+// @Tags Media
+// @Produce  json
+// @Security BearerAuth
+// @Param limit query int false "Page size, at most 200" default(50)
+// @Success 200 {array} dto.MediaDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Router /api/me/media [get]
+func (ctrl *MediaController) GetMediaLibrary(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: "Invalid limit"})
+		return
+	}
+	media, err := ctrl.mediaService.FindMedia(middleware.CurrentUser(c).ID, limit)
+	if err != nil {
+		respondMediaError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, media)
+}
+
+// GetMedia godoc
+// @Summary Get an upload
+// @Tags Media
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Media ID"
+// @Success 200 {object} dto.MediaDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/me/media/{id} [get]
+func (ctrl *MediaController) GetMedia(c *gin.Context) {
+	id, ok := mediaIdParam(c)
+	if !ok {
+		return
+	}
+	media, err := ctrl.mediaService.FindMediaById(middleware.CurrentUser(c).ID, id)
+	if err != nil {
+		respondMediaError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, media)
+}
+
+// DeleteMedia godoc
+// @Summary Delete an upload
+// @Description Delete an upload that no blog uses
+// @Tags Media
+// @Security BearerAuth
+// @Param id path int true "Media ID"
+// @Success 204
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Failure 409 {object} handler.ErrorResponse
+// @Router /api/me/media/{id} [delete]
+func (ctrl *MediaController) DeleteMedia(c *gin.Context) {
+	id, ok := mediaIdParam(c)
+	if !ok {
+		return
+	}
+	if err := ctrl.mediaService.DeleteMedia(middleware.CurrentUser(c).ID, id); err != nil {
+		respondMediaError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// mediaIdParam parses the media id path parameter, responding with 400 when it is invalid
+func mediaIdParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: "Invalid media ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// respondMediaError maps media service errors to HTTP responses
+func respondMediaError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrMediaTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, handler.ErrorResponse{Error: "Payload Too Large", Message: err.Error()})
+	case errors.Is(err, service.ErrMediaTypeNotSupported):
+		c.JSON(http.StatusUnsupportedMediaType, handler.ErrorResponse{Error: "Unsupported Media Type", Message: err.Error()})
+	case errors.Is(err, service.ErrMediaInvalid):
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: err.Error()})
+	case errors.Is(err, service.ErrMediaNotFound):
+		c.JSON(http.StatusNotFound, handler.ErrorResponse{Error: "Not Found", Message: err.Error()})
+	case errors.Is(err, service.ErrMediaInUse):
+		c.JSON(http.StatusConflict, handler.ErrorResponse{Error: "Conflict", Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, handler.ErrorResponse{Error: "Internal Server Error", Message: err.Error()})
+	}
+}
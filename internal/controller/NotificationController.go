@@ -0,0 +1,171 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"yp-blog-api/internal/dto"
+	"yp-blog-api/internal/handler"
+	"yp-blog-api/internal/middleware"
+	"yp-blog-api/internal/service"
+)
+
+// NotificationController serves the signed-in user's notification inbox and preferences.
+// Its routes are expected to sit behind middleware.RequireUser.
+type NotificationController struct {
+	notificationService service.NotificationService
+}
+
+// NewNotificationController creates a new NotificationController
+func NewNotificationController(notificationService service.NotificationService) *NotificationController {
+	return &NotificationController{
+		notificationService: notificationService,
+	}
+}
+
+// GetNotifications godoc
+// @Summary List notifications
+// @Description List the signed-in user's notifications, newest first, with the number of unread ones
+// @Tags Notification
+// @Produce  json
+// @Security BearerAuth
+// @Param unread query bool false "Only list unread notifications"
+// @Param limit query int false "Page size, at most 200" default(50)
+// @Success 200 {object} dto.NotificationListDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Router /api/me/notifications [get]
+func (ctrl *NotificationController) GetNotifications(c *gin.Context) {
+	unreadOnly, err := strconv.ParseBool(c.DefaultQuery("unread", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: "Invalid unread flag"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: "Invalid limit"})
+		return
+	}
+
+	notifications, err := ctrl.notificationService.FindNotifications(middleware.CurrentUser(c).ID, unreadOnly, limit)
+	if err != nil {
+		respondNotificationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, notifications)
+}
+
+// GetUnreadCount godoc
+// @Summary Count unread notifications
+// @Tags Notification
+// @Produce  json
+// @Security BearerAuth
+// @Success 200 {object} dto.NotificationUnreadCountDto
+// @Failure 401 {object} handler.ErrorResponse
+// @Router /api/me/notifications/unread-count [get]
+func (ctrl *NotificationController) GetUnreadCount(c *gin.Context) {
+	count, err := ctrl.notificationService.CountUnread(middleware.CurrentUser(c).ID)
+	if err != nil {
+		respondNotificationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NotificationUnreadCountDto{UnreadCount: count})
+}
+
+// MarkRead godoc
+// @Summary Mark a notification as read
+// @Tags Notification
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Notification ID"
+// @Success 200 {object} handler.SuccessResponse
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/me/notifications/{id}/read [put]
+func (ctrl *NotificationController) MarkRead(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: "Invalid notification ID"})
+		return
+	}
+	if err := ctrl.notificationService.MarkRead(middleware.CurrentUser(c).ID, uint(id)); err != nil {
+		respondNotificationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handler.SuccessResponse{Message: "Notification marked as read"})
+}
+
+// MarkAllRead godoc
+// @Summary Mark all notifications as read
+// @Tags Notification
+// @Produce  json
+// @Security BearerAuth
+// @Success 200 {object} handler.SuccessResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Router /api/me/notifications/read-all [put]
+func (ctrl *NotificationController) MarkAllRead(c *gin.Context) {
+	if err := ctrl.notificationService.MarkAllRead(middleware.CurrentUser(c).ID); err != nil {
+		respondNotificationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handler.SuccessResponse{Message: "All notifications marked as read"})
+}
+
+// GetPreferences godoc
+// @Summary Get notification preferences
+// @Description Get the preference matrix of notification type by channel
+// @Tags Notification
+// @Produce  json
+// @Security BearerAuth
+// @Success 200 {object} dto.NotificationPreferencesDto
+// @Failure 401 {object} handler.ErrorResponse
+// @Router /api/me/notification-preferences [get]
+func (ctrl *NotificationController) GetPreferences(c *gin.Context) {
+	preferences, err := ctrl.notificationService.GetPreferences(middleware.CurrentUser(c).ID)
+	if err != nil {
+		respondNotificationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, preferences)
+}
+
+// UpdatePreferences godoc
+// @Summary Update notification preferences
+// @Description Switch notification types on or off per channel; entries left out are unchanged
+// @Tags Notification
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param preferences body dto.NotificationPreferencesDto true "Preference matrix entries"
+// @Success 200 {object} dto.NotificationPreferencesDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Router /api/me/notification-preferences [put]
+func (ctrl *NotificationController) UpdatePreferences(c *gin.Context) {
+	var preferencesDto dto.NotificationPreferencesDto
+	if err := c.ShouldBindJSON(&preferencesDto); err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Invalid input", Message: "Failed to parse notification preferences"})
+		return
+	}
+
+	preferences, err := ctrl.notificationService.UpdatePreferences(middleware.CurrentUser(c).ID, preferencesDto)
+	if err != nil {
+		respondNotificationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, preferences)
+}
+
+// respondNotificationError maps notification service errors to HTTP responses
+func respondNotificationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrUnknownNotificationType), errors.Is(err, service.ErrUnknownNotificationChannel):
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: err.Error()})
+	case errors.Is(err, service.ErrNotificationNotFound):
+		c.JSON(http.StatusNotFound, handler.ErrorResponse{Error: "Not Found", Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, handler.ErrorResponse{Error: "Internal Server Error", Message: err.Error()})
+	}
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"yp-blog-api/internal/handler"
+	"yp-blog-api/internal/service"
+)
+
+// OEmbedController is the oEmbed provider endpoint for post URLs
+type OEmbedController struct {
+	oEmbedService service.OEmbedService
+}
+
+// NewOEmbedController creates a new OEmbedController
+func NewOEmbedController(oEmbedService service.OEmbedService) *OEmbedController {
+	return &OEmbedController{
+		oEmbedService: oEmbedService,
+	}
+}
+
+// GetEmbed godoc
+// @Summary oEmbed provider
+// @Description Describe a post URL (https://<site>/@author/slug) as an oEmbed rich card with its thumbnail, author and reading time.
+// @Description Only the json format is supported.
+// @Tags Embed
+// @Produce  json
+// @Param url query string true "Post URL"
+// @Param format query string false "Response format" default(json)
+// @Param maxwidth query int false "Maximum width of the card"
+// @Param maxheight query int false "Maximum height of the card"
+// @Success 200 {object} dto.OEmbedDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Failure 501 {object} handler.ErrorResponse
+// @Router /oembed [get]
+func (ctrl *OEmbedController) GetEmbed(c *gin.Context) {
+	if format := c.DefaultQuery("format", "json"); format != "json" {
+		c.JSON(http.StatusNotImplemented, handler.ErrorResponse{Error: "Not Implemented", Message: "Only the json format is supported"})
+		return
+	}
+	postURL := c.Query("url")
+	if postURL == "" {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: "The url parameter is required"})
+		return
+	}
+	maxWidth, errWidth := strconv.Atoi(c.DefaultQuery("maxwidth", "0"))
+	maxHeight, errHeight := strconv.Atoi(c.DefaultQuery("maxheight", "0"))
+	if errWidth != nil || errHeight != nil || maxWidth < 0 || maxHeight < 0 {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: "Invalid maxwidth or maxheight"})
+		return
+	}
+
+	embed, err := ctrl.oEmbedService.FindEmbed(postURL, maxWidth, maxHeight, requestOrigin(c))
+	if err != nil {
+		if errors.Is(err, service.ErrOEmbedURLNotSupported) || errors.Is(err, service.ErrBlogNotFound) {
+			c.JSON(http.StatusNotFound, handler.ErrorResponse{Error: "Not Found", Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, handler.ErrorResponse{Error: "Internal Server Error", Message: err.Error()})
+		return
+	}
+	c.Header("Cache-Control", "public, max-age="+strconv.Itoa(embed.CacheAge))
+	c.JSON(http.StatusOK, embed)
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"yp-blog-api/internal/handler"
+	"yp-blog-api/internal/middleware"
+	"yp-blog-api/internal/service"
+)
+
+// visitorIdHeader carries the anonymous visitor id generated and kept by the client
+const visitorIdHeader = "X-Visitor-Id"
+
+type ReactionController struct {
+	reactionService service.ReactionService
+}
+
+// NewReactionController creates a new ReactionController
+func NewReactionController(reactionService service.ReactionService) *ReactionController {
+	return &ReactionController{
+		reactionService: reactionService,
+	}
+}
+
+// GetReactions godoc
+// @Summary Get the reactions of a blog
+// @Description Count the reactions of a blog by type, along with the caller's own reactions
+// @Tags Reaction
+// @Produce  json
+// @Param author path string true "Author Name"
+// @Param slug path string true "Blog Slug"
+// @Param X-Visitor-Id header string false "Anonymous visitor id"
+// @Success 200 {object} dto.ReactionSummaryDto
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/blogs/:author/:slug/reactions [get]
+func (ctrl *ReactionController) GetReactions(c *gin.Context) {
+	summary, err := ctrl.reactionService.GetReactions(c.Param("author"), c.Param("slug"), reactorFromRequest(c))
+	if err != nil {
+		respondReactionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, summary)
+}
+
+// AddReaction godoc
+// @Summary React to a blog
+// @Description Add a reaction of the given type. Reacting twice with the same type has no further effect.
+// @Tags Reaction
+// @Produce  json
+// @Param author path string true "Author Name"
+// @Param slug path string true "Blog Slug"
+// @Param type path string true "Reaction type"
+// @Param X-Visitor-Id header string false "Anonymous visitor id"
+// @Success 200 {object} dto.ReactionSummaryDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/blogs/:author/:slug/reactions/{type} [put]
+func (ctrl *ReactionController) AddReaction(c *gin.Context) {
+	summary, err := ctrl.reactionService.AddReaction(c.Param("author"), c.Param("slug"), reactorFromRequest(c), c.Param("type"))
+	if err != nil {
+		respondReactionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, summary)
+}
+
+// RemoveReaction godoc
+// @Summary Remove a reaction from a blog
+// @Description Remove a reaction of the given type. Removing a reaction that does not exist has no effect.
+// @Tags Reaction
+// @Produce  json
+// @Param author path string true "Author Name"
+// @Param slug path string true "Blog Slug"
+// @Param type path string true "Reaction type"
+// @Param X-Visitor-Id header string false "Anonymous visitor id"
+// @Success 200 {object} dto.ReactionSummaryDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/blogs/:author/:slug/reactions/{type} [delete]
+func (ctrl *ReactionController) RemoveReaction(c *gin.Context) {
+	summary, err := ctrl.reactionService.RemoveReaction(c.Param("author"), c.Param("slug"), reactorFromRequest(c), c.Param("type"))
+	if err != nil {
+		respondReactionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, summary)
+}
+
+// reactorFromRequest identifies the signed-in user or anonymous visitor making the request
+func reactorFromRequest(c *gin.Context) service.Reactor {
+	return service.Reactor{UserID: middleware.CurrentUserID(c), VisitorID: c.GetHeader(visitorIdHeader)}
+}
+
+// respondReactionError maps reaction service errors to HTTP responses
+func respondReactionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrUnknownReactionType), errors.Is(err, service.ErrReactorRequired):
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: err.Error()})
+	case errors.Is(err, service.ErrBlogNotFound):
+		c.JSON(http.StatusNotFound, handler.ErrorResponse{Error: "Not Found", Message: "Blog not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, handler.ErrorResponse{Error: "Internal Server Error", Message: err.Error()})
+	}
+}
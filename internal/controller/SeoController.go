@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/url"
+	"yp-blog-api/internal/handler"
+	"yp-blog-api/internal/service"
+)
+
+// SeoController serves the metadata the frontend renders into the head of post pages
+type SeoController struct {
+	seoService service.SeoService
+}
+
+// NewSeoController creates a new SeoController
+func NewSeoController(seoService service.SeoService) *SeoController {
+	return &SeoController{
+		seoService: seoService,
+	}
+}
+
+// GetSeoMetadata godoc
+// @Summary Get the SEO metadata of a blog
+// @Description Canonical URL, Open Graph and Twitter card tags and schema.org BlogPosting JSON-LD of a published blog.
+// @Description The blog's SEO title and description override its title and summary. oembedUrl is the post's oEmbed discovery link.
+// @Tags Blog
+// @Produce  json
+// @Param author path string true "Author Name"
+// @Param slug path string true "Blog Slug"
+// @Success 200 {object} dto.SeoMetadataDto
+// @Success 301 "Blog moved; the Location header holds the metadata URL of the canonical @author/slug"
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/blogs/:author/:slug/seo [get]
+func (ctrl *SeoController) GetSeoMetadata(c *gin.Context) {
+	metadata, err := ctrl.seoService.FindSeoMetadataByAuthorAndSlug(c.Param("author"), c.Param("slug"), requestOrigin(c))
+	if err != nil {
+		var moved *service.BlogMovedError
+		if errors.As(err, &moved) {
+			c.Redirect(http.StatusMovedPermanently, moved.Location()+"/seo")
+			return
+		}
+		if errors.Is(err, service.ErrBlogNotFound) {
+			c.JSON(http.StatusNotFound, handler.ErrorResponse{Error: "Not Found", Message: "Blog not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, handler.ErrorResponse{Error: "Internal Server Error", Message: err.Error()})
+		return
+	}
+	// The oEmbed provider is served by this API, which is not necessarily on the site's host
+	metadata.OEmbedURL = requestOrigin(c) + "/oembed?format=json&url=" + url.QueryEscape(metadata.CanonicalURL)
+	c.JSON(http.StatusOK, metadata)
+}
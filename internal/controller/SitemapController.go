@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"yp-blog-api/internal/handler"
+	"yp-blog-api/internal/service"
+	"yp-blog-api/internal/syndication"
+)
+
+// SitemapController serves the sitemap index and its sitemaps to search engines
+type SitemapController struct {
+	sitemapService service.SitemapService
+}
+
+// NewSitemapController creates a new SitemapController
+func NewSitemapController(sitemapService service.SitemapService) *SitemapController {
+	return &SitemapController{
+		sitemapService: sitemapService,
+	}
+}
+
+// GetSitemapIndex godoc
+// @Summary Sitemap index
+// @Description Sitemap index pointing at sitemaps of at most 50,000 URLs each, covering posts, category pages and author pages
+// @Tags Sitemap
+// @Produce  application/xml
+// @Success 200 {string} string "Sitemap index"
+// @Success 304
+// @Router /sitemap.xml [get]
+func (ctrl *SitemapController) GetSitemapIndex(c *gin.Context) {
+	pages, err := ctrl.sitemapService.FindSitemapPages()
+	if err != nil {
+		respondSitemapError(c, err)
+		return
+	}
+	origin := requestOrigin(c)
+	sitemaps := make([]syndication.SitemapURL, len(pages))
+	var lastModified time.Time
+	for i, page := range pages {
+		sitemaps[i] = syndication.SitemapURL{Loc: fmt.Sprintf("%s/sitemaps/%d.xml", origin, page.Number), LastMod: page.LastMod}
+		if page.LastMod.After(lastModified) {
+			lastModified = page.LastMod
+		}
+	}
+	body, err := syndication.EncodeSitemapIndex(sitemaps)
+	if err != nil {
+		respondSitemapError(c, err)
+		return
+	}
+	writeCacheable(c, syndication.ContentTypeSitemap, body, lastModified, ctrl.sitemapService.HasSiteURL())
+}
+
+// GetSitemap godoc
+// @Summary Sitemap
+// @Description One sitemap of the sitemap index, listing each page with its last modification time
+// @Tags Sitemap
+// @Produce  application/xml
+// @Param page path string true "Sitemap file, e.g. 1.xml"
+// @Success 200 {string} string "Sitemap"
+// @Success 304
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /sitemaps/{page} [get]
+func (ctrl *SitemapController) GetSitemap(c *gin.Context) {
+	number, err := strconv.Atoi(strings.TrimSuffix(c.Param("page"), ".xml"))
+	if err != nil {
+		respondSitemapError(c, service.ErrSitemapPageNotFound)
+		return
+	}
+	urls, err := ctrl.sitemapService.FindSitemapPage(number, requestOrigin(c))
+	if err != nil {
+		respondSitemapError(c, err)
+		return
+	}
+	var lastModified time.Time
+	for _, url := range urls {
+		if url.LastMod.After(lastModified) {
+			lastModified = url.LastMod
+		}
+	}
+	body, err := syndication.EncodeSitemap(urls)
+	if err != nil {
+		respondSitemapError(c, err)
+		return
+	}
+	writeCacheable(c, syndication.ContentTypeSitemap, body, lastModified, ctrl.sitemapService.HasSiteURL())
+}
+
+// RebuildSitemap godoc
+// @Summary Rebuild the sitemap
+// @Description Queue a background job that rebuilds every sitemap entry from the published blogs
+// @Tags Sitemap
+// @Produce  json
+// @Security BearerAuth
+// @Success 202 {object} dto.JobDto
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Router /api/admin/sitemap/rebuild [post]
+func (ctrl *SitemapController) RebuildSitemap(c *gin.Context) {
+	job, err := ctrl.sitemapService.ScheduleRebuild()
+	if err != nil {
+		respondSitemapError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, job)
+}
+
+// respondSitemapError maps sitemap service errors to HTTP responses
+func respondSitemapError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrSitemapPageNotFound):
+		c.JSON(http.StatusNotFound, handler.ErrorResponse{Error: "Not Found", Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, handler.ErrorResponse{Error: "Internal Server Error", Message: err.Error()})
+	}
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+	"yp-blog-api/internal/handler"
+	"yp-blog-api/internal/middleware"
+	"yp-blog-api/internal/pubsub"
+)
+
+const (
+	// streamTopicNotifications subscribes the signed-in user to their own notifications
+	streamTopicNotifications = "notifications"
+	// streamBufferSize is how many events a slow client may fall behind before it is dropped
+	streamBufferSize = 32
+	// streamHeartbeatInterval keeps idle connections open through proxies
+	streamHeartbeatInterval = 15 * time.Second
+	// streamEventOverflow tells a client it was dropped for falling behind and should reconnect
+	streamEventOverflow = "overflow"
+)
+
+// StreamController streams live events to clients as server-sent events
+type StreamController struct {
+	bus *pubsub.Bus
+}
+
+// NewStreamController creates a new StreamController
+func NewStreamController(bus *pubsub.Bus) *StreamController {
+	return &StreamController{
+		bus: bus,
+	}
+}
+
+// Stream godoc
+// @Summary Stream live events
+// @Description Stream new posts, new comments, view counts and notifications as server-sent events.
+// @Description Topics are "posts", "blog:<username>/<slug>", "category:<slug>", "author:<username>" and, for signed-in users, "notifications".
+// @Description Clients that fall behind receive an "overflow" event and are disconnected.
+// @Tags Stream
+// @Produce  text/event-stream
+// @Security BearerAuth
+// @Param topic query []string false "Topics to subscribe to" collectionFormat(multi) default(posts)
+// @Success 200 {string} string "Event stream"
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Router /api/stream [get]
+func (ctrl *StreamController) Stream(c *gin.Context) {
+	requested := c.QueryArray("topic")
+	if len(requested) == 0 {
+		requested = []string{pubsub.TopicPosts}
+	}
+	topics := make([]string, 0, len(requested))
+	for _, topic := range requested {
+		if topic == streamTopicNotifications {
+			userID := middleware.CurrentUserID(c)
+			if userID == nil {
+				c.JSON(http.StatusUnauthorized, handler.ErrorResponse{Error: "Unauthorized", Message: "Sign in to stream notifications"})
+				return
+			}
+			topics = append(topics, pubsub.UserTopic(*userID))
+			continue
+		}
+		if !pubsub.IsPublicTopic(topic) {
+			c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: "Invalid topic: " + topic})
+			return
+		}
+		topics = append(topics, topic)
+	}
+
+	sub := ctrl.bus.Subscribe(topics, streamBufferSize)
+	defer ctrl.bus.Unsubscribe(sub)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := c.Writer.WriteString(": heartbeat\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case event, ok := <-sub.Events():
+			if !ok {
+				if sub.Overflowed() {
+					c.SSEvent(streamEventOverflow, gin.H{"message": "Too many pending events, reconnect to resume"})
+					c.Writer.Flush()
+				}
+				return
+			}
+			c.SSEvent(event.Type, event.Data)
+			c.Writer.Flush()
+		}
+	}
+}
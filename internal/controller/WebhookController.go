@@ -0,0 +1,278 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+	"strconv"
+	"yp-blog-api/internal/dto"
+	"yp-blog-api/internal/handler"
+	"yp-blog-api/internal/service"
+)
+
+// WebhookController lets administrators manage webhook subscriptions and inspect their deliveries
+type WebhookController struct {
+	webhookService service.WebhookService
+}
+
+// NewWebhookController creates a new WebhookController
+func NewWebhookController(webhookService service.WebhookService) *WebhookController {
+	return &WebhookController{
+		webhookService: webhookService,
+	}
+}
+
+// GetWebhooks godoc
+// @Summary List webhooks
+// @Tags Webhook
+// @Produce  json
+// @Security BearerAuth
+// @Success 200 {array} dto.WebhookDto
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Router /api/admin/webhooks [get]
+func (ctrl *WebhookController) GetWebhooks(c *gin.Context) {
+	webhooks, err := ctrl.webhookService.FindWebhooks()
+	if err != nil {
+		respondWebhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, webhooks)
+}
+
+// GetWebhook godoc
+// @Summary Get a webhook
+// @Tags Webhook
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} dto.WebhookDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/admin/webhooks/{id} [get]
+func (ctrl *WebhookController) GetWebhook(c *gin.Context) {
+	id, _, ok := webhookIdParams(c, false)
+	if !ok {
+		return
+	}
+	webhook, err := ctrl.webhookService.FindWebhook(id)
+	if err != nil {
+		respondWebhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, webhook)
+}
+
+// CreateWebhook godoc
+// @Summary Create a webhook
+// @Description Subscribe a URL to content events (blog.published, blog.updated, blog.deleted).
+// @Description Requests carry an X-Webhook-Signature header of the form "sha256=<hex>", the HMAC-SHA256
+// @Description of "<X-Webhook-Timestamp>.<body>" keyed with the secret, which is only returned here.
+// @Tags Webhook
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param webhook body dto.WebhookRequestDto true "Webhook"
+// @Success 201 {object} dto.WebhookDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Router /api/admin/webhooks [post]
+func (ctrl *WebhookController) CreateWebhook(c *gin.Context) {
+	var webhookDto dto.WebhookRequestDto
+	if err := c.ShouldBindJSON(&webhookDto); err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Invalid input", Message: "Failed to parse webhook data"})
+		return
+	}
+	webhook, err := ctrl.webhookService.CreateWebhook(webhookDto)
+	if err != nil {
+		respondWebhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, webhook)
+}
+
+// UpdateWebhook godoc
+// @Summary Update a webhook
+// @Description Change a webhook's URL, events or status. Set rotateSecret to get a new signing secret.
+// @Tags Webhook
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Param webhook body dto.WebhookRequestDto true "Webhook"
+// @Success 200 {object} dto.WebhookDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/admin/webhooks/{id} [put]
+func (ctrl *WebhookController) UpdateWebhook(c *gin.Context) {
+	id, _, ok := webhookIdParams(c, false)
+	if !ok {
+		return
+	}
+	var webhookDto dto.WebhookRequestDto
+	if err := c.ShouldBindJSON(&webhookDto); err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Invalid input", Message: "Failed to parse webhook data"})
+		return
+	}
+	webhook, err := ctrl.webhookService.UpdateWebhook(id, webhookDto)
+	if err != nil {
+		respondWebhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, webhook)
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a webhook
+// @Description Delete a webhook together with its delivery log
+// @Tags Webhook
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Success 204
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/admin/webhooks/{id} [delete]
+func (ctrl *WebhookController) DeleteWebhook(c *gin.Context) {
+	id, _, ok := webhookIdParams(c, false)
+	if !ok {
+		return
+	}
+	if err := ctrl.webhookService.DeleteWebhook(id); err != nil {
+		respondWebhookError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetDeliveries godoc
+// @Summary List webhook deliveries
+// @Description List the delivery log of a webhook, newest first
+// @Tags Webhook
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Param status query string false "Delivery status (pending, succeeded, failed)"
+// @Param limit query int false "Page size, at most 200" default(50)
+// @Success 200 {array} dto.WebhookDeliveryDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/admin/webhooks/{id}/deliveries [get]
+func (ctrl *WebhookController) GetDeliveries(c *gin.Context) {
+	id, _, ok := webhookIdParams(c, false)
+	if !ok {
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: "Invalid limit"})
+		return
+	}
+	deliveries, err := ctrl.webhookService.FindDeliveries(id, c.Query("status"), limit)
+	if err != nil {
+		respondWebhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// GetDelivery godoc
+// @Summary Get a webhook delivery
+// @Description Get a delivery with its payload and every attempt made so far
+// @Tags Webhook
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Param deliveryId path int true "Delivery ID"
+// @Success 200 {object} dto.WebhookDeliveryDetailDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/admin/webhooks/{id}/deliveries/{deliveryId} [get]
+func (ctrl *WebhookController) GetDelivery(c *gin.Context) {
+	id, deliveryId, ok := webhookIdParams(c, true)
+	if !ok {
+		return
+	}
+	delivery, err := ctrl.webhookService.FindDelivery(id, deliveryId)
+	if err != nil {
+		respondWebhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, delivery)
+}
+
+// Redeliver godoc
+// @Summary Redeliver a webhook delivery
+// @Description Queue a delivery again with a fresh set of attempts
+// @Tags Webhook
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Param deliveryId path int true "Delivery ID"
+// @Success 202 {object} dto.WebhookDeliveryDto
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/admin/webhooks/{id}/deliveries/{deliveryId}/redeliver [post]
+func (ctrl *WebhookController) Redeliver(c *gin.Context) {
+	id, deliveryId, ok := webhookIdParams(c, true)
+	if !ok {
+		return
+	}
+	delivery, err := ctrl.webhookService.Redeliver(id, deliveryId)
+	if err != nil {
+		respondWebhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, delivery)
+}
+
+// webhookIdParams parses the webhook id path parameter and, when withDelivery is set,
+// the delivery id, responding with 400 when one is invalid
+func webhookIdParams(c *gin.Context, withDelivery bool) (uint, uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: "Invalid webhook ID"})
+		return 0, 0, false
+	}
+	if !withDelivery {
+		return uint(id), 0, true
+	}
+	deliveryId, err := strconv.ParseUint(c.Param("deliveryId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: "Invalid delivery ID"})
+		return 0, 0, false
+	}
+	return uint(id), uint(deliveryId), true
+}
+
+// respondWebhookError maps webhook service errors to HTTP responses
+func respondWebhookError(c *gin.Context, err error) {
+	var validationErrors validator.ValidationErrors
+	switch {
+	case errors.As(err, &validationErrors):
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{
+			Error:   "Validation Failed",
+			Message: "Some fields did not pass validation",
+			Fields:  handler.FormatValidationErrors(validationErrors),
+		})
+	case errors.Is(err, service.ErrInvalidWebhookURL), errors.Is(err, service.ErrUnknownDeliveryStatus):
+		c.JSON(http.StatusBadRequest, handler.ErrorResponse{Error: "Bad Request", Message: err.Error()})
+	case errors.Is(err, service.ErrWebhookNotFound), errors.Is(err, service.ErrWebhookDeliveryNotFound):
+		c.JSON(http.StatusNotFound, handler.ErrorResponse{Error: "Not Found", Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, handler.ErrorResponse{Error: "Internal Server Error", Message: err.Error()})
+	}
+}
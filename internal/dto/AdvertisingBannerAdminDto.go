@@ -0,0 +1,24 @@
+package dto
+
+// AdvertisingBannerAdminDto is an advertising banner as shown to administrators
+type AdvertisingBannerAdminDto struct {
+	ID        int64  `json:"id"`
+	Title     string `json:"title"`
+	ImageURL  string `json:"imageUrl"`
+	Link      string `json:"link"`
+	IsDeleted bool   `json:"isDeleted"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
+
+	// StartsAt and EndsAt are RFC 3339 times in the banner's time zone, empty when open
+	StartsAt           string `json:"startsAt,omitempty"`
+	EndsAt             string `json:"endsAt,omitempty"`
+	Timezone           string `json:"timezone"`
+	DailyImpressionCap int64  `json:"dailyImpressionCap"`
+	Priority           int    `json:"priority"`
+	ImpressionsToday   int64  `json:"impressionsToday"`
+	// Status is scheduled, active, capped, ended or deleted
+	Status string `json:"status"`
+	// Categories are the slugs of the categories the banner is targeted at, empty for all
+	Categories []string `json:"categories"`
+}
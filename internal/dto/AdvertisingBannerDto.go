@@ -4,5 +4,6 @@ type AdvertisingBannerDto struct {
 	ID       int64  `json:"id"`
 	Title    string `json:"title"`
 	ImageURL string `json:"imageUrl"`
-	Link     string `json:"link"`
+	// Link goes through the /r/banner/{id} redirect, which counts the click
+	Link string `json:"link"`
 }
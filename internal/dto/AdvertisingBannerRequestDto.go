@@ -0,0 +1,32 @@
+package dto
+
+import "github.com/go-playground/validator/v10"
+
+// AdvertisingBannerRequestDto is the payload for creating or updating an advertising banner
+type AdvertisingBannerRequestDto struct {
+	Title string `json:"title" validate:"required,max=255"`
+	// ImageURL is an http(s) URL or a path on this site, such as an uploaded image's /media/... URL
+	ImageURL string `json:"imageUrl" validate:"required,max=255"`
+	// Link is the http(s) URL readers are taken to
+	Link string `json:"link" validate:"required,url,max=255"`
+
+	// StartsAt and EndsAt bound the campaign, as RFC 3339 times or as local times such as
+	// 2024-06-01T09:00 in Timezone; leave one empty to keep the campaign open on that side
+	StartsAt string `json:"startsAt"`
+	EndsAt   string `json:"endsAt"`
+	// Timezone is an IANA time zone such as Europe/Paris; it defaults to UTC
+	Timezone string `json:"timezone" validate:"omitempty,max=64"`
+	// DailyImpressionCap limits how many times a day the banner is shown; 0 means no cap
+	DailyImpressionCap int64 `json:"dailyImpressionCap" validate:"min=0"`
+	// Priority weighs how often the banner is picked against the other active ones; it defaults to 1
+	Priority int `json:"priority" validate:"omitempty,min=1,max=100"`
+	// Categories are the slugs of the categories whose listings show the banner; leave it
+	// empty to show the banner on every listing
+	Categories []string `json:"categories" validate:"max=20,dive,required,max=100"`
+}
+
+// Validate function to validate the AdvertisingBannerRequestDto struct
+func (b *AdvertisingBannerRequestDto) Validate() error {
+	validate := validator.New()
+	return validate.Struct(b)
+}
@@ -1,7 +1,11 @@
 package dto
 
 type AuthorCardDetailDto struct {
-	ProfileImage string `json:"profileImage"`
-	UserName     string `json:"userName"`
-	Bio          string `json:"bio"`
+	ProfileImage   string `json:"profileImage"`
+	UserName       string `json:"userName"`
+	Bio            string `json:"bio"`
+	FollowerCount  int64  `json:"followerCount"`
+	FollowingCount int64  `json:"followingCount"`
+	// Following tells whether the signed-in reader follows the author
+	Following bool `json:"following"`
 }
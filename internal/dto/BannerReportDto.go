@@ -0,0 +1,24 @@
+package dto
+
+// BannerReportDto is the performance of a banner over a range of days
+type BannerReportDto struct {
+	BannerID int64  `json:"bannerId"`
+	Title    string `json:"title"`
+	// From and To are the first and last day of the report, both included
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Impressions int64  `json:"impressions"`
+	Clicks      int64  `json:"clicks"`
+	// CTR is the click-through rate, clicks divided by impressions, 0 without impressions
+	CTR float64 `json:"ctr"`
+	// Days has one entry per day of the range when the report is for a single banner
+	Days []BannerDayReportDto `json:"days,omitempty"`
+}
+
+// BannerDayReportDto is the performance of a banner on a day of its time zone
+type BannerDayReportDto struct {
+	Day         string  `json:"day"`
+	Impressions int64   `json:"impressions"`
+	Clicks      int64   `json:"clicks"`
+	CTR         float64 `json:"ctr"`
+}
@@ -1,13 +1,20 @@
 package dto
 
 type BlogCardDto struct {
-	Slug                 string        `json:"slug"`
-	Thumbnail            string        `json:"thumbnail"`
-	Summary              string        `json:"summary"`
-	BlogTitle            string        `json:"blogTitle"`
-	FormattedCountViewer string        `json:"formattedCountViewer"`
-	MinRead              int           `json:"minRead"`
-	Published            bool          `json:"published"`
-	Author               AuthorCardDto `json:"author"`
-	CreatedAt            string        `json:"createdAt"`
+	Slug                 string           `json:"slug"`
+	Thumbnail            string           `json:"thumbnail"`
+	Summary              string           `json:"summary"`
+	BlogTitle            string           `json:"blogTitle"`
+	FormattedCountViewer string           `json:"formattedCountViewer"`
+	MinRead              int              `json:"minRead"`
+	CommentCount         int64            `json:"commentCount"`
+	Reactions            map[string]int64 `json:"reactions"`
+	ReactionCount        int64            `json:"reactionCount"`
+	Bookmarked           bool             `json:"bookmarked"`
+	Published            bool             `json:"published"`
+	Author               AuthorCardDto    `json:"author"`
+	CreatedAt            string           `json:"createdAt"`
+
+	// ThumbnailImage has the variants of the thumbnail when it is an upload
+	ThumbnailImage *ResponsiveImageDto `json:"thumbnailImage,omitempty"`
 }
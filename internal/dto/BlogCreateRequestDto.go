@@ -10,13 +10,17 @@ type BlogCreateRequestDto struct {
 	BlogTitle   string `json:"blogTitle" validate:"required,max=500"`
 	Published   bool   `json:"published" validate:"required"`
 	BlogContent string `json:"blogContent" validate:"required"`
-	Slug        string `json:"slug"`
+	Slug        string `json:"slug" validate:"omitempty,max=200"`
 	IsPin       bool   `json:"isPin" `
 	Thumbnail   string `json:"thumbnail" validate:"omitempty,max=255"`
 	Summary     string `json:"summary" validate:"omitempty,max=500"`
 	MinRead     int    `json:"minRead" validate:"required,min=1"`
 	CategoryIds []int  `json:"categoryIds"`
 	Tags        []int  `json:"tags"`
+
+	// SeoTitle and SeoDescription override the title and summary in search results and link previews
+	SeoTitle       string `json:"seoTitle" validate:"omitempty,max=120"`
+	SeoDescription string `json:"seoDescription" validate:"omitempty,max=320"`
 }
 
 // Validate function to validate the BlogCreateRequestDto struct
@@ -5,6 +5,9 @@ type BlogDetailDto struct {
 	Slug                 string              `json:"slug"`
 	BlogContent          string              `json:"blogContent"`
 	Summary              string              `json:"summary"`
+	SummaryAutoGenerated bool                `json:"summaryAutoGenerated"`
+	SeoTitle             string              `json:"seoTitle,omitempty"`
+	SeoDescription       string              `json:"seoDescription,omitempty"`
 	Thumbnail            string              `json:"thumbnail"`
 	BlogTitle            string              `json:"blogTitle"`
 	FormattedCountViewer string              `json:"formattedCountViewer"`
@@ -13,6 +16,14 @@ type BlogDetailDto struct {
 	Author               AuthorCardDetailDto `json:"author"`
 	CreatedAt            string              `json:"createdAt"`
 	LastModifiedTimeAgo  string              `json:"lastModifiedTimeAgo"`
+	PublishedAt          string              `json:"publishedAt"` // RFC 3339
+	ModifiedAt           string              `json:"modifiedAt"`  // RFC 3339
 	Categories           []CategoryDto       `json:"categories"`
 	Tags                 []TagDto            `json:"tags"`
+	TableOfContents      []TocEntryDto       `json:"tableOfContents"`
+	Reactions            map[string]int64    `json:"reactions"`
+	ReactionCount        int64               `json:"reactionCount"`
+
+	// ThumbnailImage has the variants of the thumbnail when it is an upload
+	ThumbnailImage *ResponsiveImageDto `json:"thumbnailImage,omitempty"`
 }
@@ -0,0 +1,21 @@
+package dto
+
+import "github.com/go-playground/validator/v10"
+
+// BlogOrderRequestDto lists blogs in their new order. Saved blogs that are not
+// listed keep their relative order after the listed ones.
+type BlogOrderRequestDto struct {
+	Blogs []BlogRefDto `json:"blogs" validate:"required,min=1,max=500,dive"`
+}
+
+// BlogRefDto names a blog by its author and slug, since slugs are only unique per author
+type BlogRefDto struct {
+	Author string `json:"author" validate:"required"`
+	Slug   string `json:"slug" validate:"required"`
+}
+
+// Validate function to validate the BlogOrderRequestDto struct
+func (b *BlogOrderRequestDto) Validate() error {
+	validate := validator.New()
+	return validate.Struct(b)
+}
@@ -9,10 +9,16 @@ type BlogUpdateRequestDto struct {
 	BlogTitle   string `json:"blogTitle" validate:"required,max=255"`
 	Published   bool   `json:"published" validate:"required"`
 	BlogContent string `json:"blogContent" validate:"required"`
+	Slug        string `json:"slug" validate:"omitempty,max=200"`
 	IsPin       bool   `json:"isPin" validate:"required"`
 	Thumbnail   string `json:"thumbnail" validate:"omitempty,max=255"`
 	Summary     string `json:"summary" validate:"omitempty,max=500"`
 	MinRead     int    `json:"minRead" validate:"required,min=1"`
+
+	// SeoTitle and SeoDescription override the title and summary in search results and link
+	// previews. They are kept when omitted and cleared when empty.
+	SeoTitle       *string `json:"seoTitle" validate:"omitempty,max=120"`
+	SeoDescription *string `json:"seoDescription" validate:"omitempty,max=320"`
 }
 
 // Validate function to validate the BlogUpdateRequestDto struct
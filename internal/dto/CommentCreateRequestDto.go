@@ -0,0 +1,17 @@
+package dto
+
+import "github.com/go-playground/validator/v10"
+
+// CommentCreateRequestDto is the payload for posting a comment or a reply. Guests sign
+// their comment with a name; signed-in readers comment under their user name.
+type CommentCreateRequestDto struct {
+	ParentID  *uint  `json:"parentId"`
+	GuestName string `json:"guestName" validate:"omitempty,max=100"`
+	Body      string `json:"body" validate:"required,max=5000"`
+}
+
+// Validate function to validate the CommentCreateRequestDto struct
+func (c *CommentCreateRequestDto) Validate() error {
+	validate := validator.New()
+	return validate.Struct(c)
+}
@@ -0,0 +1,17 @@
+package dto
+
+// CommentDto is a comment in a threaded comment listing
+type CommentDto struct {
+	ID        uint          `json:"id"`
+	ParentID  *uint         `json:"parentId"`
+	Author    AuthorCardDto `json:"author"`
+	Guest     bool          `json:"guest"`
+	Body      string        `json:"body"`
+	Status    string        `json:"status"`
+	Deleted   bool          `json:"deleted"`
+	Edited    bool          `json:"edited"`
+	CreatedAt string        `json:"createdAt"`
+	Replies   []CommentDto  `json:"replies"`
+	// EditToken is only returned when the comment is created and is required to edit or delete it
+	EditToken string `json:"editToken,omitempty"`
+}
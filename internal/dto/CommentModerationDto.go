@@ -0,0 +1,17 @@
+package dto
+
+// CommentModerationDto is a comment as shown in the admin moderation queue
+type CommentModerationDto struct {
+	ID          uint          `json:"id"`
+	BlogSlug    string        `json:"blogSlug"`
+	BlogTitle   string        `json:"blogTitle"`
+	BlogAuthor  string        `json:"blogAuthor"`
+	ParentID    *uint         `json:"parentId"`
+	Author      AuthorCardDto `json:"author"`
+	Body        string        `json:"body"`
+	Status      string        `json:"status"`
+	SpamScore   float64       `json:"spamScore"`
+	SpamReasons []string      `json:"spamReasons"`
+	IPAddress   string        `json:"ipAddress"`
+	CreatedAt   string        `json:"createdAt"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+import "github.com/go-playground/validator/v10"
+
+// CommentModerationRequestDto is the payload for a moderation decision on a comment
+type CommentModerationRequestDto struct {
+	Status string `json:"status" validate:"required,oneof=pending approved rejected spam"`
+}
+
+// Validate function to validate the CommentModerationRequestDto struct
+func (c *CommentModerationRequestDto) Validate() error {
+	validate := validator.New()
+	return validate.Struct(c)
+}
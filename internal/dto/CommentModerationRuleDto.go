@@ -0,0 +1,15 @@
+package dto
+
+import "github.com/go-playground/validator/v10"
+
+// CommentModerationRuleDto is an author's auto-approve rule for comments on their blogs
+type CommentModerationRuleDto struct {
+	UserName string `json:"userName"`
+	Mode     string `json:"mode" validate:"required,oneof=auto_approve auto_approve_trusted manual"`
+}
+
+// Validate function to validate the CommentModerationRuleDto struct
+func (c *CommentModerationRuleDto) Validate() error {
+	validate := validator.New()
+	return validate.Struct(c)
+}
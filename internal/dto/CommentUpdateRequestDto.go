@@ -0,0 +1,14 @@
+package dto
+
+import "github.com/go-playground/validator/v10"
+
+// CommentUpdateRequestDto is the payload for editing a comment
+type CommentUpdateRequestDto struct {
+	Body string `json:"body" validate:"required,max=5000"`
+}
+
+// Validate function to validate the CommentUpdateRequestDto struct
+func (c *CommentUpdateRequestDto) Validate() error {
+	validate := validator.New()
+	return validate.Struct(c)
+}
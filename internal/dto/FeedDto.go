@@ -0,0 +1,8 @@
+package dto
+
+// FeedDto is a page of the signed-in reader's personalized feed
+type FeedDto struct {
+	Blogs []BlogCardDto `json:"blogs"`
+	// NextCursor fetches the following page and is empty on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
+}
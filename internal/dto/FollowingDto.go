@@ -0,0 +1,7 @@
+package dto
+
+// FollowingDto lists the authors and tags the signed-in reader follows
+type FollowingDto struct {
+	Authors []AuthorCardDto `json:"authors"`
+	Tags    []TagDto        `json:"tags"`
+}
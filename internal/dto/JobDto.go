@@ -0,0 +1,16 @@
+package dto
+
+// JobDto is a background job as shown to administrators
+type JobDto struct {
+	ID          uint   `json:"id"`
+	Type        string `json:"type"`
+	Payload     string `json:"payload"`
+	UniqueKey   string `json:"uniqueKey"`
+	Status      string `json:"status"`
+	Attempts    int    `json:"attempts"`
+	MaxAttempts int    `json:"maxAttempts"`
+	RunAt       string `json:"runAt"`
+	LastError   string `json:"lastError"`
+	CreatedAt   string `json:"createdAt"`
+	FinishedAt  string `json:"finishedAt,omitempty"`
+}
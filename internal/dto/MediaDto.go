@@ -0,0 +1,24 @@
+package dto
+
+// MediaDto is a file of the signed-in user's media library
+type MediaDto struct {
+	ID           uint   `json:"id"`
+	URL          string `json:"url"`
+	OriginalName string `json:"originalName"`
+	ContentType  string `json:"contentType"`
+	Size         int64  `json:"size"`
+	CreatedAt    string `json:"createdAt"`
+	// Image has the dimensions and variants of the file once they are generated
+	Image ResponsiveImageDto `json:"image"`
+	// References lists the blogs using the file; files in use cannot be deleted
+	References []MediaReferenceDto `json:"references"`
+}
+
+type MediaReferenceDto struct {
+	BlogID    uint   `json:"blogId"`
+	BlogTitle string `json:"blogTitle"`
+	Author    string `json:"author"`
+	Slug      string `json:"slug"`
+	// Field is where the blog uses the file: thumbnail or content
+	Field string `json:"field"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+// NotificationDto is a message in the signed-in user's inbox
+type NotificationDto struct {
+	ID        uint   `json:"id"`
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Message   string `json:"message"`
+	Link      string `json:"link"`
+	Read      bool   `json:"read"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// NotificationListDto is a page of the inbox along with the total number of unread notifications
+type NotificationListDto struct {
+	Notifications []NotificationDto `json:"notifications"`
+	UnreadCount   int64             `json:"unreadCount"`
+}
+
+// NotificationUnreadCountDto holds the number of unread notifications
+type NotificationUnreadCountDto struct {
+	UnreadCount int64 `json:"unreadCount"`
+}
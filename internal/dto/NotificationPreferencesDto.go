@@ -0,0 +1,7 @@
+package dto
+
+// NotificationPreferencesDto is the preference matrix of a user, keyed by
+// notification type and then by channel. When updating, omitted entries are left unchanged.
+type NotificationPreferencesDto struct {
+	Preferences map[string]map[string]bool `json:"preferences"`
+}
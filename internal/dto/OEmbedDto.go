@@ -0,0 +1,22 @@
+package dto
+
+// OEmbedDto is an oEmbed 1.0 rich response describing an embeddable post card
+type OEmbedDto struct {
+	Type            string `json:"type"`
+	Version         string `json:"version"`
+	Title           string `json:"title"`
+	AuthorName      string `json:"author_name"`
+	AuthorURL       string `json:"author_url"`
+	ProviderName    string `json:"provider_name"`
+	ProviderURL     string `json:"provider_url"`
+	CacheAge        int    `json:"cache_age"`
+	ThumbnailURL    string `json:"thumbnail_url,omitempty"`
+	ThumbnailWidth  int    `json:"thumbnail_width,omitempty"`
+	ThumbnailHeight int    `json:"thumbnail_height,omitempty"`
+	HTML            string `json:"html"`
+	Width           int    `json:"width"`
+	Height          int    `json:"height"`
+	// Description and ReadingTime are extensions for consumers that build their own card
+	Description string `json:"description"`
+	ReadingTime int    `json:"reading_time"` // minutes
+}
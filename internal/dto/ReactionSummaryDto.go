@@ -0,0 +1,8 @@
+package dto
+
+// ReactionSummaryDto holds the reaction counts of a blog and the caller's own reactions
+type ReactionSummaryDto struct {
+	Counts  map[string]int64 `json:"counts"`
+	Total   int64            `json:"total"`
+	Reacted []string         `json:"reacted"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+// ReadingListDto is a reader's named reading list
+type ReadingListDto struct {
+	ID        uint   `json:"id"`
+	Name      string `json:"name"`
+	ItemCount int64  `json:"itemCount"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// ReadingListDetailDto is a reading list together with its blogs in list order
+type ReadingListDetailDto struct {
+	ReadingListDto
+	Blogs []BlogCardDto `json:"blogs"`
+}
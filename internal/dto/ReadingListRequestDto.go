@@ -0,0 +1,14 @@
+package dto
+
+import "github.com/go-playground/validator/v10"
+
+// ReadingListRequestDto is the payload for creating or renaming a reading list
+type ReadingListRequestDto struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+// Validate function to validate the ReadingListRequestDto struct
+func (r *ReadingListRequestDto) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
@@ -0,0 +1,24 @@
+package dto
+
+// ResponsiveImageDto is an uploaded image with its scaled down variants, ready for an
+// <img src srcset> element
+type ResponsiveImageDto struct {
+	// Src is the URL of the image as uploaded
+	Src    string `json:"src"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+	// Srcset lists the variants and the original with their widths, e.g.
+	// "https://.../1-320w.jpg 320w, https://.../1.jpg 2000w"; empty until they are generated
+	Srcset string `json:"srcset"`
+	// Placeholder is a tiny data URI version of the image, to show blurred while it loads
+	Placeholder string           `json:"placeholder,omitempty"`
+	Sources     []ImageSourceDto `json:"sources"`
+}
+
+// ImageSourceDto is one size of an image, smallest first
+type ImageSourceDto struct {
+	URL         string `json:"url"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	ContentType string `json:"contentType"`
+}
@@ -0,0 +1,48 @@
+package dto
+
+// SeoMetadataDto holds what a page needs in its head to be indexed and previewed well
+type SeoMetadataDto struct {
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	CanonicalURL string `json:"canonicalUrl"`
+	// OpenGraph tags are rendered as <meta property="..." content="...">
+	OpenGraph []SeoMetaTagDto `json:"openGraph"`
+	// Twitter tags are rendered as <meta name="..." content="...">
+	Twitter []SeoMetaTagDto `json:"twitter"`
+	// OEmbedURL is advertised with <link rel="alternate" type="application/json+oembed">
+	OEmbedURL string `json:"oembedUrl"`
+	// JSONLD is rendered in a <script type="application/ld+json"> element
+	JSONLD BlogPostingJSONLDDto `json:"jsonLd"`
+}
+
+type SeoMetaTagDto struct {
+	Property string `json:"property,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Content  string `json:"content"`
+}
+
+// BlogPostingJSONLDDto is a schema.org BlogPosting
+type BlogPostingJSONLDDto struct {
+	Context          string         `json:"@context"`
+	Type             string         `json:"@type"`
+	Headline         string         `json:"headline"`
+	Description      string         `json:"description"`
+	URL              string         `json:"url"`
+	Image            []string       `json:"image,omitempty"`
+	DatePublished    string         `json:"datePublished"`
+	DateModified     string         `json:"dateModified"`
+	Author           JSONLDThingDto `json:"author"`
+	Publisher        JSONLDThingDto `json:"publisher"`
+	MainEntityOfPage JSONLDThingDto `json:"mainEntityOfPage"`
+	ArticleSection   []string       `json:"articleSection,omitempty"`
+	Keywords         []string       `json:"keywords,omitempty"`
+	TimeRequired     string         `json:"timeRequired,omitempty"`
+}
+
+// JSONLDThingDto is a schema.org Person, Organization or WebPage referenced by a BlogPosting
+type JSONLDThingDto struct {
+	Type string `json:"@type"`
+	ID   string `json:"@id,omitempty"`
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
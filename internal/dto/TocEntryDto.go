@@ -0,0 +1,9 @@
+package dto
+
+// TocEntryDto is a heading in the generated table of contents of a blog
+type TocEntryDto struct {
+	Level    int           `json:"level"`
+	Text     string        `json:"text"`
+	Anchor   string        `json:"anchor"`
+	Children []TocEntryDto `json:"children,omitempty"`
+}
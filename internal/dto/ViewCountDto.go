@@ -0,0 +1,8 @@
+package dto
+
+// ViewCountDto is the live view count of a blog
+type ViewCountDto struct {
+	Author string `json:"author"`
+	Slug   string `json:"slug"`
+	Views  int    `json:"views"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+// WebhookDeliveryDto is an entry of a webhook's delivery log
+type WebhookDeliveryDto struct {
+	ID             uint   `json:"id"`
+	Event          string `json:"event"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	LastStatusCode int    `json:"lastStatusCode"`
+	LastError      string `json:"lastError"`
+	NextAttemptAt  string `json:"nextAttemptAt,omitempty"`
+	CreatedAt      string `json:"createdAt"`
+}
+
+// WebhookDeliveryDetailDto is a delivery with its payload and every attempt made so far
+type WebhookDeliveryDetailDto struct {
+	WebhookDeliveryDto
+	Payload     string                      `json:"payload"`
+	AttemptsLog []WebhookDeliveryAttemptDto `json:"attemptsLog"`
+}
+
+// WebhookDeliveryAttemptDto is the outcome of one request made for a delivery
+type WebhookDeliveryAttemptDto struct {
+	StatusCode int    `json:"statusCode"`
+	Error      string `json:"error"`
+	DurationMs int64  `json:"durationMs"`
+	CreatedAt  string `json:"createdAt"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+// WebhookDto is a webhook subscription as shown to administrators
+type WebhookDto struct {
+	ID          uint     `json:"id"`
+	URL         string   `json:"url"`
+	Description string   `json:"description"`
+	Events      []string `json:"events"`
+	Active      bool     `json:"active"`
+	CreatedAt   string   `json:"createdAt"`
+	// Secret is only returned when the webhook is created or its secret is rotated
+	Secret string `json:"secret,omitempty"`
+}
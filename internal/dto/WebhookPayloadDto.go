@@ -0,0 +1,25 @@
+package dto
+
+import "time"
+
+// WebhookPayloadDto is the JSON body posted to webhook receivers
+type WebhookPayloadDto struct {
+	Event     string       `json:"event"`
+	CreatedAt time.Time    `json:"createdAt"`
+	Blog      BlogEventDto `json:"blog"`
+}
+
+// BlogEventDto describes the blog a content event is about
+type BlogEventDto struct {
+	ID         uint     `json:"id"`
+	Slug       string   `json:"slug"`
+	BlogTitle  string   `json:"blogTitle"`
+	Summary    string   `json:"summary"`
+	Thumbnail  string   `json:"thumbnail"`
+	Author     string   `json:"author"`
+	Link       string   `json:"link"`
+	Categories []string `json:"categories"`
+	Tags       []string `json:"tags"`
+	Published  bool     `json:"published"`
+	Deleted    bool     `json:"deleted"`
+}
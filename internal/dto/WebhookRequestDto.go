@@ -0,0 +1,20 @@
+package dto
+
+import "github.com/go-playground/validator/v10"
+
+// WebhookRequestDto is the payload for creating or updating a webhook
+type WebhookRequestDto struct {
+	URL         string   `json:"url" validate:"required,url,max=500"`
+	Description string   `json:"description" validate:"omitempty,max=200"`
+	Events      []string `json:"events" validate:"required,min=1,dive,oneof=blog.published blog.updated blog.deleted"`
+	// Active defaults to true when omitted
+	Active *bool `json:"active"`
+	// RotateSecret generates a new signing secret when updating a webhook
+	RotateSecret bool `json:"rotateSecret"`
+}
+
+// Validate function to validate the WebhookRequestDto struct
+func (w *WebhookRequestDto) Validate() error {
+	validate := validator.New()
+	return validate.Struct(w)
+}
@@ -0,0 +1,46 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	// Register the formats Decode reads
+	_ "golang.org/x/image/webp"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// MaxPixels bounds the size of the images decoded. A small file can declare a huge
+// image, and decoding allocates memory for every pixel it declares.
+const MaxPixels = 40_000_000
+
+// ErrImageTooLarge is returned for images with more pixels than MaxPixels
+var ErrImageTooLarge = fmt.Errorf("image is larger than %d megapixels", MaxPixels/1_000_000)
+
+// CheckSize reads the dimensions an image declares, without decoding it, and fails with
+// ErrImageTooLarge when it has more pixels than MaxPixels. Images of a format without
+// a registered decoder pass, as they are never decoded.
+func CheckSize(data []byte) error {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if errors.Is(err, image.ErrFormat) {
+		return nil
+	}
+	if err != nil {
+		return ErrInvalidImage
+	}
+	if int64(config.Width)*int64(config.Height) > MaxPixels {
+		return ErrImageTooLarge
+	}
+	return nil
+}
+
+// Decode decodes an image once CheckSize has let it through
+func Decode(data []byte) (image.Image, error) {
+	if err := CheckSize(data); err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	return img, err
+}
@@ -0,0 +1,47 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/jpeg"
+	"image/png"
+)
+
+// placeholderWidth is the width of blur placeholders; browsers scale them up blurred
+const placeholderWidth = 16
+
+// Encode encodes a derivative of an image: as JPEG when it is opaque, as PNG when it
+// has transparency. WebP would be smaller, but the standard library cannot encode it.
+// It returns the encoded image and its content type.
+func Encode(img image.Image) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if isOpaque(img) {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "image/jpeg", nil
+	}
+	encoder := png.Encoder{CompressionLevel: png.BestCompression}
+	if err := encoder.Encode(&buf, img); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "image/png", nil
+}
+
+// Placeholder renders a tiny version of an image as a data URI, to show blurred while
+// the image itself loads
+func Placeholder(img image.Image) (string, error) {
+	encoded, contentType, err := Encode(Resize(img, placeholderWidth))
+	if err != nil {
+		return "", err
+	}
+	return "data:" + contentType + ";base64," + base64.StdEncoding.EncodeToString(encoded), nil
+}
+
+func isOpaque(img image.Image) bool {
+	if opaque, ok := img.(interface{ Opaque() bool }); ok {
+		return opaque.Opaque()
+	}
+	return false
+}
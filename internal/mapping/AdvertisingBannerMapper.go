@@ -1,6 +1,8 @@
 package mapper
 
 import (
+	"strconv"
+	"time"
 	"yp-blog-api/internal/dto"
 	"yp-blog-api/internal/models"
 )
@@ -8,6 +10,7 @@ import (
 type AdvertisingBannerMapper interface {
 	AdvertisingBannerToDto(advertisingBanner models.AdvertisingBanner) dto.AdvertisingBannerDto
 	AdvertisingBannerListToDtoList(advertisingBanners []models.AdvertisingBanner) []dto.AdvertisingBannerDto
+	AdvertisingBannerToAdminDto(advertisingBanner models.AdvertisingBanner) dto.AdvertisingBannerAdminDto
 }
 
 type advertisingBannerMapperImpl struct{}
@@ -16,19 +19,54 @@ func NewAdvertisingBannerMapper() AdvertisingBannerMapper {
 	return &advertisingBannerMapperImpl{}
 }
 
+// AdvertisingBannerToDto maps a banner for readers; its link is the click tracking redirect
 func (m *advertisingBannerMapperImpl) AdvertisingBannerToDto(advertisingBanner models.AdvertisingBanner) dto.AdvertisingBannerDto {
 	return dto.AdvertisingBannerDto{
 		ID:       advertisingBanner.ID,
 		Title:    advertisingBanner.Title,
 		ImageURL: advertisingBanner.ImageURL,
-		Link:     advertisingBanner.Link,
+		Link:     BannerClickPath(advertisingBanner.ID),
 	}
 }
 
+// BannerClickPath is the path of the redirect that counts a click on a banner
+func BannerClickPath(id int64) string {
+	return "/r/banner/" + strconv.FormatInt(id, 10)
+}
+
 func (m *advertisingBannerMapperImpl) AdvertisingBannerListToDtoList(advertisingBanners []models.AdvertisingBanner) []dto.AdvertisingBannerDto {
-	var dtos []dto.AdvertisingBannerDto
+	dtos := make([]dto.AdvertisingBannerDto, 0, len(advertisingBanners))
 	for _, banner := range advertisingBanners {
 		dtos = append(dtos, m.AdvertisingBannerToDto(banner))
 	}
 	return dtos
 }
+
+// AdvertisingBannerToAdminDto maps a banner for administrators; its campaign times are
+// shown in the banner's time zone. Impressions and status are left to the caller.
+func (m *advertisingBannerMapperImpl) AdvertisingBannerToAdminDto(advertisingBanner models.AdvertisingBanner) dto.AdvertisingBannerAdminDto {
+	bannerDto := dto.AdvertisingBannerAdminDto{
+		ID:                 advertisingBanner.ID,
+		Title:              advertisingBanner.Title,
+		ImageURL:           advertisingBanner.ImageURL,
+		Link:               advertisingBanner.Link,
+		IsDeleted:          advertisingBanner.IsDeleted,
+		CreatedAt:          GetTimeAgo(advertisingBanner.CreatedAt),
+		UpdatedAt:          GetTimeAgo(advertisingBanner.UpdatedAt),
+		Timezone:           advertisingBanner.Timezone,
+		DailyImpressionCap: advertisingBanner.DailyImpressionCap,
+		Priority:           advertisingBanner.Priority,
+		Categories:         make([]string, 0, len(advertisingBanner.Categories)),
+	}
+	for _, category := range advertisingBanner.Categories {
+		bannerDto.Categories = append(bannerDto.Categories, category.Slug)
+	}
+	location := advertisingBanner.Location()
+	if advertisingBanner.StartsAt != nil {
+		bannerDto.StartsAt = advertisingBanner.StartsAt.In(location).Format(time.RFC3339)
+	}
+	if advertisingBanner.EndsAt != nil {
+		bannerDto.EndsAt = advertisingBanner.EndsAt.In(location).Format(time.RFC3339)
+	}
+	return bannerDto
+}
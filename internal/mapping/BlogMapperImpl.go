@@ -3,9 +3,11 @@ package mapper
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 	dto2 "yp-blog-api/internal/dto"
 	models2 "yp-blog-api/internal/models"
+	"yp-blog-api/internal/utils"
 )
 
 type blogMapperImpl struct{}
@@ -43,10 +45,15 @@ func (m *blogMapperImpl) BlogToBlogCardDtoSingle(blog models2.Blog) dto2.BlogCar
 
 // BlogToBlogDetailDto Map a single Blog entity to BlogDetailDto
 func (m *blogMapperImpl) BlogToBlogDetailDto(blog models2.Blog) dto2.BlogDetailDto {
+	// Render the content with heading anchors so the table of contents can link into it
+	content, headings := utils.BuildTableOfContents(blog.BlogContent)
 	return dto2.BlogDetailDto{
 		Slug:                 blog.Slug,
-		BlogContent:          blog.BlogContent,
+		BlogContent:          content,
 		Summary:              blog.Summary,
+		SummaryAutoGenerated: blog.SummaryAutoGenerated,
+		SeoTitle:             blog.SeoTitle,
+		SeoDescription:       blog.SeoDescription,
 		Thumbnail:            blog.Thumbnail,
 		BlogTitle:            blog.BlogTitle,
 		FormattedCountViewer: m.formatCountViewer(blog.CountViewer),
@@ -59,8 +66,11 @@ func (m *blogMapperImpl) BlogToBlogDetailDto(blog models2.Blog) dto2.BlogDetailD
 		},
 		CreatedAt:           GetTimeAgo(blog.CreatedAt),
 		LastModifiedTimeAgo: GetTimeAgo(blog.UpdatedAt),
+		PublishedAt:         blog.CreatedAt.UTC().Format(time.RFC3339),
+		ModifiedAt:          blog.UpdatedAt.UTC().Format(time.RFC3339),
 		Categories:          mapCategories(blog.Categories),
 		Tags:                mapTags(blog.Tags),
+		TableOfContents:     mapTableOfContents(headings),
 	}
 }
 
@@ -106,14 +116,16 @@ func (m *blogMapperImpl) ToTopAuthorDTOList(results [][]interface{}) []*dto2.Top
 // CreateBlogDtoToBlog Map BlogCreateRequestDto to Blog entity
 func (m *blogMapperImpl) CreateBlogDtoToBlog(dto dto2.BlogCreateRequestDto) models2.Blog {
 	return models2.Blog{
-		BlogTitle:   dto.BlogTitle,
-		Published:   dto.Published,
-		BlogContent: dto.BlogContent,
-		Slug:        dto.Slug,
-		IsPin:       dto.IsPin,
-		Thumbnail:   dto.Thumbnail,
-		Summary:     dto.Summary,
-		MinRead:     dto.MinRead,
+		BlogTitle:      dto.BlogTitle,
+		Published:      dto.Published,
+		BlogContent:    dto.BlogContent,
+		Slug:           dto.Slug,
+		IsPin:          dto.IsPin,
+		Thumbnail:      dto.Thumbnail,
+		Summary:        dto.Summary,
+		MinRead:        dto.MinRead,
+		SeoTitle:       strings.TrimSpace(dto.SeoTitle),
+		SeoDescription: strings.TrimSpace(dto.SeoDescription),
 		// Additional fields can be mapped as needed
 	}
 }
@@ -138,6 +150,12 @@ func (m *blogMapperImpl) UpdateBlog(blog *models2.Blog, dto dto2.BlogUpdateReque
 	if dto.MinRead > 0 {
 		blog.MinRead = dto.MinRead
 	}
+	if dto.SeoTitle != nil {
+		blog.SeoTitle = strings.TrimSpace(*dto.SeoTitle)
+	}
+	if dto.SeoDescription != nil {
+		blog.SeoDescription = strings.TrimSpace(*dto.SeoDescription)
+	}
 	if dto.IsPin {
 		blog.IsPin = dto.IsPin
 	}
@@ -149,15 +167,16 @@ func (m *blogMapperImpl) BlogDtoToBlogAdminDto(blogs []models2.Blog) []dto2.Blog
 	var dtos []dto2.BlogAdminDto
 	for _, blog := range blogs {
 		dtos = append(dtos, dto2.BlogAdminDto{
-			ID:          int(blog.ID),
-			BlogTitle:   blog.BlogTitle,
-			Published:   blog.Published,
-			Slug:        blog.Slug,
-			IsPin:       blog.IsPin,
-			Thumbnail:   blog.Thumbnail,
-			CountViewer: blog.CountViewer,
-			Summary:     blog.Summary,
-			MinRead:     blog.MinRead,
+			ID:                   int(blog.ID),
+			BlogTitle:            blog.BlogTitle,
+			Published:            blog.Published,
+			Slug:                 blog.Slug,
+			IsPin:                blog.IsPin,
+			Thumbnail:            blog.Thumbnail,
+			CountViewer:          blog.CountViewer,
+			Summary:              blog.Summary,
+			SummaryAutoGenerated: blog.SummaryAutoGenerated,
+			MinRead:              blog.MinRead,
 			Author: dto2.UserDto{
 				UserName:     blog.Author.UserName,
 				ProfileImage: blog.Author.ProfileImage,
@@ -207,6 +226,19 @@ func mapTags(tags []models2.Tag) []dto2.TagDto {
 	return dtos
 }
 
+func mapTableOfContents(headings []utils.TocHeading) []dto2.TocEntryDto {
+	var dtos []dto2.TocEntryDto
+	for _, heading := range headings {
+		dtos = append(dtos, dto2.TocEntryDto{
+			Level:    heading.Level,
+			Text:     heading.Text,
+			Anchor:   heading.Anchor,
+			Children: mapTableOfContents(heading.Children),
+		})
+	}
+	return dtos
+}
+
 func GetTimeAgo(t time.Time) string {
 	now := time.Now()
 	duration := now.Sub(t)
@@ -0,0 +1,116 @@
+package mapper
+
+import (
+	"strings"
+	"yp-blog-api/internal/dto"
+	"yp-blog-api/internal/models"
+)
+
+type CommentMapper interface {
+	CommentToDto(comment models.Comment) dto.CommentDto
+	CommentsToThread(comments []models.Comment) []dto.CommentDto
+	CreateDtoToComment(commentDto dto.CommentCreateRequestDto) models.Comment
+	CommentsToModerationDtos(comments []models.Comment) []dto.CommentModerationDto
+}
+
+type commentMapperImpl struct{}
+
+func NewCommentMapper() CommentMapper {
+	return &commentMapperImpl{}
+}
+
+func (m *commentMapperImpl) CommentToDto(comment models.Comment) dto.CommentDto {
+	commentDto := dto.CommentDto{
+		ID:        comment.ID,
+		ParentID:  comment.ParentID,
+		Guest:     comment.Author == nil,
+		Body:      comment.Body,
+		Status:    comment.Status,
+		Deleted:   comment.IsDeleted,
+		Edited:    comment.EditedAt != nil,
+		CreatedAt: GetTimeAgo(comment.CreatedAt),
+		Replies:   []dto.CommentDto{},
+	}
+	if comment.Author != nil {
+		commentDto.Author = dto.AuthorCardDto{
+			UserName:     comment.Author.UserName,
+			ProfileImage: comment.Author.ProfileImage,
+		}
+	} else {
+		commentDto.Author = dto.AuthorCardDto{UserName: comment.GuestName}
+	}
+	// Deleted comments stay in the thread as placeholders without their content
+	if comment.IsDeleted {
+		commentDto.Body = ""
+		commentDto.Author = dto.AuthorCardDto{}
+	}
+	return commentDto
+}
+
+// CommentsToThread nests comments under their parents. Deleted comments are kept
+// only when they still have visible replies, so threads never lose their shape.
+func (m *commentMapperImpl) CommentsToThread(comments []models.Comment) []dto.CommentDto {
+	children := make(map[uint][]models.Comment)
+	known := make(map[uint]bool, len(comments))
+	for _, comment := range comments {
+		known[comment.ID] = true
+	}
+	var roots []models.Comment
+	for _, comment := range comments {
+		if comment.ParentID == nil || !known[*comment.ParentID] {
+			roots = append(roots, comment)
+			continue
+		}
+		children[*comment.ParentID] = append(children[*comment.ParentID], comment)
+	}
+	return m.buildThread(roots, children)
+}
+
+func (m *commentMapperImpl) buildThread(comments []models.Comment, children map[uint][]models.Comment) []dto.CommentDto {
+	thread := []dto.CommentDto{}
+	for _, comment := range comments {
+		commentDto := m.CommentToDto(comment)
+		commentDto.Replies = m.buildThread(children[comment.ID], children)
+		if comment.IsDeleted && len(commentDto.Replies) == 0 {
+			continue
+		}
+		thread = append(thread, commentDto)
+	}
+	return thread
+}
+
+func (m *commentMapperImpl) CreateDtoToComment(commentDto dto.CommentCreateRequestDto) models.Comment {
+	return models.Comment{
+		ParentID:  commentDto.ParentID,
+		GuestName: commentDto.GuestName,
+		Body:      commentDto.Body,
+		Status:    models.CommentStatusApproved,
+	}
+}
+
+func (m *commentMapperImpl) CommentsToModerationDtos(comments []models.Comment) []dto.CommentModerationDto {
+	dtos := []dto.CommentModerationDto{}
+	for _, comment := range comments {
+		moderationDto := dto.CommentModerationDto{
+			ID:          comment.ID,
+			ParentID:    comment.ParentID,
+			Author:      m.CommentToDto(comment).Author,
+			Body:        comment.Body,
+			Status:      comment.Status,
+			SpamScore:   comment.SpamScore,
+			SpamReasons: []string{},
+			IPAddress:   comment.IPAddress,
+			CreatedAt:   GetTimeAgo(comment.CreatedAt),
+		}
+		if comment.SpamReasons != "" {
+			moderationDto.SpamReasons = strings.Split(comment.SpamReasons, "; ")
+		}
+		if comment.Blog != nil {
+			moderationDto.BlogSlug = comment.Blog.Slug
+			moderationDto.BlogTitle = comment.Blog.BlogTitle
+			moderationDto.BlogAuthor = comment.Blog.Author.UserName
+		}
+		dtos = append(dtos, moderationDto)
+	}
+	return dtos
+}
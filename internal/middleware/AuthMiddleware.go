@@ -0,0 +1,221 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+	"yp-blog-api/internal/handler"
+	"yp-blog-api/internal/models"
+	"yp-blog-api/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// currentUserKey is the gin context key holding the authenticated *models.User
+const currentUserKey = "currentUser"
+
+// tokenErrorKey is the gin context key holding why a presented token was not accepted
+const tokenErrorKey = "tokenError"
+
+// adminKey is the gin context key set for users signed in with an administrator's email
+const adminKey = "admin"
+
+var errInvalidToken = errors.New("invalid or expired access token")
+
+// tokenClaims are the JWT claims issued by the account service; the subject is the user's email
+type tokenClaims struct {
+	Subject   string `json:"sub"`
+	ExpiresAt int64  `json:"exp"`
+	NotBefore int64  `json:"nbf"`
+	IssuedAt  int64  `json:"iat"`
+}
+
+// tokenClockSkew is how far ahead of this server's clock nbf and iat may be
+const tokenClockSkew = time.Minute
+
+// Authenticate resolves the user behind an "Authorization: Bearer <jwt>" header.
+// Tokens are HS256 JWTs signed with secret. Requests without a valid token continue
+// anonymously, so a stale token does not break public pages; an invalid token is
+// reported in the WWW-Authenticate header and rejected by RequireUser. Users whose
+// email is one of adminEmails are administrators.
+func Authenticate(userRepo repositories.UserRepository, secret string, adminEmails []string) gin.HandlerFunc {
+	admins := make(map[string]bool, len(adminEmails))
+	for _, email := range adminEmails {
+		if email = strings.ToLower(strings.TrimSpace(email)); email != "" {
+			admins[email] = true
+		}
+	}
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			c.Next()
+			return
+		}
+
+		token, found := strings.CutPrefix(header, "Bearer ")
+		if !found || secret == "" {
+			rejectToken(c, errInvalidToken)
+			return
+		}
+		claims, err := parseToken(token, secret)
+		if err != nil {
+			rejectToken(c, err)
+			return
+		}
+		user, err := userRepo.FindByEmail(claims.Subject)
+		if err != nil {
+			rejectToken(c, errInvalidToken)
+			return
+		}
+
+		c.Set(currentUserKey, user)
+		if admins[strings.ToLower(user.Email)] {
+			c.Set(adminKey, true)
+		}
+		c.Next()
+	}
+}
+
+// rejectToken drops a token that could not be verified and lets the request continue anonymously
+func rejectToken(c *gin.Context, err error) {
+	c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+	c.Set(tokenErrorKey, err)
+	c.Next()
+}
+
+// RequireUser rejects requests that were not authenticated by Authenticate
+func RequireUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !requireSignedIn(c) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireAdmin rejects requests that were not authenticated as an administrator
+func RequireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !requireSignedIn(c) {
+			return
+		}
+		if !IsAdmin(c) {
+			abortForbidden(c, errors.New("only administrators can use this endpoint"))
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireSelfOrAdmin rejects requests unless they were authenticated as the user named by
+// the path parameter param, or as an administrator
+func RequireSelfOrAdmin(param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !requireSignedIn(c) {
+			return
+		}
+		if CurrentUser(c).UserName != c.Param(param) && !IsAdmin(c) {
+			abortForbidden(c, errors.New("only this user or an administrator can use this endpoint"))
+			return
+		}
+		c.Next()
+	}
+}
+
+// requireSignedIn aborts anonymous requests, telling why a presented token was refused
+func requireSignedIn(c *gin.Context) bool {
+	if CurrentUser(c) != nil {
+		return true
+	}
+	if err, ok := c.Get(tokenErrorKey); ok {
+		abortUnauthorized(c, err.(error))
+		return false
+	}
+	abortUnauthorized(c, errors.New("sign in to use this endpoint"))
+	return false
+}
+
+// IsAdmin reports whether the request was authenticated as an administrator
+func IsAdmin(c *gin.Context) bool {
+	return c.GetBool(adminKey)
+}
+
+// CurrentUser returns the authenticated user, or nil for anonymous requests
+func CurrentUser(c *gin.Context) *models.User {
+	if user, ok := c.Get(currentUserKey); ok {
+		return user.(*models.User)
+	}
+	return nil
+}
+
+// CurrentUserID returns the id of the authenticated user, or nil for anonymous requests
+func CurrentUserID(c *gin.Context) *uint {
+	if user := CurrentUser(c); user != nil {
+		return &user.ID
+	}
+	return nil
+}
+
+// parseToken verifies the signature and expiry of an HS256 JWT and returns its claims
+func parseToken(token string, secret string) (*tokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errInvalidToken
+	}
+
+	var header struct {
+		Algorithm string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil || header.Algorithm != "HS256" {
+		return nil, errInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errInvalidToken
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, errInvalidToken
+	}
+
+	var claims tokenClaims
+	if err := decodeSegment(parts[1], &claims); err != nil || claims.Subject == "" {
+		return nil, errInvalidToken
+	}
+	// Tokens must expire, and must not be used before they are valid
+	now := time.Now().Unix()
+	if claims.ExpiresAt == 0 || now >= claims.ExpiresAt {
+		return nil, errInvalidToken
+	}
+	skew := int64(tokenClockSkew / time.Second)
+	if claims.NotBefore != 0 && now+skew < claims.NotBefore {
+		return nil, errInvalidToken
+	}
+	if claims.IssuedAt != 0 && now+skew < claims.IssuedAt {
+		return nil, errInvalidToken
+	}
+	return &claims, nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func abortUnauthorized(c *gin.Context, err error) {
+	c.AbortWithStatusJSON(http.StatusUnauthorized, handler.ErrorResponse{Error: "Unauthorized", Message: err.Error()})
+}
+
+func abortForbidden(c *gin.Context, err error) {
+	c.AbortWithStatusJSON(http.StatusForbidden, handler.ErrorResponse{Error: "Forbidden", Message: err.Error()})
+}
@@ -13,4 +13,58 @@ type AdvertisingBanner struct {
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdAt"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
 	IsDeleted bool      `gorm:"default:false" json:"isDeleted"`
+
+	// StartsAt and EndsAt bound the campaign; an unset bound leaves it open on that side
+	StartsAt *time.Time `gorm:"index" json:"startsAt"`
+	EndsAt   *time.Time `gorm:"index" json:"endsAt"`
+	// Timezone is the IANA time zone the campaign is planned in; daily caps reset at its midnight
+	Timezone string `gorm:"type:varchar(64);default:UTC" json:"timezone"`
+	// DailyImpressionCap is how many times a day the banner is shown at most, 0 for no cap
+	DailyImpressionCap int64 `gorm:"default:0" json:"dailyImpressionCap"`
+	// Priority weighs how often the banner is picked against the other active ones
+	Priority int `gorm:"default:1" json:"priority"`
+	// Categories target the banner at the listings of those categories; a banner without
+	// categories is shown on every listing
+	Categories []Category `gorm:"many2many:advertising_banner_categories;" json:"categories"`
+}
+
+// Location is the time zone of the banner, UTC when it is unset or unknown
+func (b *AdvertisingBanner) Location() *time.Location {
+	if location, err := time.LoadLocation(b.Timezone); err == nil {
+		return location
+	}
+	return time.UTC
+}
+
+// TargetsCategory tells whether the banner is shown on the listing of the category with
+// the given slug, an empty slug being the listing of all categories
+func (b *AdvertisingBanner) TargetsCategory(slug string) bool {
+	if len(b.Categories) == 0 {
+		return true
+	}
+	for _, category := range b.Categories {
+		if category.Slug == slug {
+			return true
+		}
+	}
+	return false
+}
+
+// Day is the date, in the banner's time zone, the given time falls on
+func (b *AdvertisingBanner) Day(t time.Time) string {
+	return t.In(b.Location()).Format("2006-01-02")
+}
+
+// BannerDailyStat counts how often a banner was shown and clicked on a day of its time zone
+type BannerDailyStat struct {
+	ID       int64 `gorm:"primaryKey;autoIncrement"`
+	BannerID int64 `gorm:"not null;uniqueIndex:idx_banner_daily_stat"`
+	// Day is the date in the banner's time zone, e.g. 2024-05-31
+	Day         string `gorm:"type:varchar(10);not null;uniqueIndex:idx_banner_daily_stat"`
+	Impressions int64  `gorm:"not null;default:0"`
+	Clicks      int64  `gorm:"not null;default:0"`
+}
+
+func (BannerDailyStat) TableName() string {
+	return "banner_daily_stats"
 }
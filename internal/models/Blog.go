@@ -1,29 +1,53 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Blog struct {
-	ID          uint       `gorm:"primaryKey" json:"id"`
-	BlogTitle   string     `gorm:"type:varchar(256);not null"`
-	Published   bool       `gorm:"default:false" json:"published"`
-	BlogContent string     `gorm:"type:text;not null"`
-	Slug        string     `gorm:"type:varchar(256);not null;unique"`
-	IsPin       bool       `gorm:"default:false"`
-	Thumbnail   string     `gorm:"type:varchar(256)"`
-	CountViewer int        `gorm:"type:int"`
-	Summary     string     `gorm:"type:text" json:"summary"`
-	MinRead     int        `gorm:"type:tinyint"`
-	ParentID    *uint      `gorm:"index"`
-	Parent      *Blog      `gorm:"foreignKey:ParentID"`
-	AuthorID    uint       `gorm:"index"`
-	Author      User       `gorm:"foreignKey:AuthorID"`
-	Tags        []Tag      `gorm:"many2many:blog_tags;"`
-	Categories  []Category `gorm:"many2many:blog_categories;"`
-	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"createdAt"`
-	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updatedAt"`
-	IsDeleted   bool       `gorm:"default:false" json:"isDeleted"`
+	ID                   uint       `gorm:"primaryKey" json:"id"`
+	BlogTitle            string     `gorm:"type:varchar(256);not null"`
+	Published            bool       `gorm:"default:false" json:"published"`
+	BlogContent          string     `gorm:"type:text;not null"`
+	Slug                 string     `gorm:"type:varchar(256);not null;uniqueIndex:idx_blogs_author_slug"` // Unique per author
+	IsPin                bool       `gorm:"default:false"`
+	Thumbnail            string     `gorm:"type:varchar(256)"`
+	CountViewer          int        `gorm:"type:int"`
+	Summary              string     `gorm:"type:text" json:"summary"`
+	SummaryAutoGenerated bool       `gorm:"default:false" json:"summaryAutoGenerated"`
+	SeoTitle             string     `gorm:"type:varchar(120)" json:"seoTitle"`       // Overrides the title in search results and link previews
+	SeoDescription       string     `gorm:"type:varchar(320)" json:"seoDescription"` // Overrides the summary in search results and link previews
+	MinRead              int        `gorm:"type:tinyint"`
+	ParentID             *uint      `gorm:"index"`
+	Parent               *Blog      `gorm:"foreignKey:ParentID"`
+	AuthorID             uint       `gorm:"index;uniqueIndex:idx_blogs_author_slug"`
+	Author               User       `gorm:"foreignKey:AuthorID"`
+	Tags                 []Tag      `gorm:"many2many:blog_tags;"`
+	Categories           []Category `gorm:"many2many:blog_categories;"`
+	CreatedAt            time.Time  `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt            time.Time  `gorm:"autoUpdateTime" json:"updatedAt"`
+	IsDeleted            bool       `gorm:"default:false" json:"isDeleted"`
 }
 
 func (Blog) TableName() string {
 	return "blogs"
 }
+
+// BeforeUpdate is a GORM hook that records the previous slug when it changes, so
+// links to the old slug can be redirected
+func (b *Blog) BeforeUpdate(tx *gorm.DB) (err error) {
+	if b.ID == 0 || b.Slug == "" {
+		return
+	}
+	db := tx.Session(&gorm.Session{NewDB: true})
+	var previous string
+	if err = db.Model(&Blog{}).Select("slug").Where("id = ?", b.ID).Scan(&previous).Error; err != nil {
+		return
+	}
+	if previous == "" || previous == b.Slug {
+		return
+	}
+	return db.Create(&BlogSlugHistory{BlogID: b.ID, Slug: previous}).Error
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// BlogSlugHistory keeps the slugs a blog was previously published under
+type BlogSlugHistory struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	BlogID    uint      `gorm:"index;not null"`
+	Slug      string    `gorm:"type:varchar(256);not null;index"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdAt"`
+}
+
+func (BlogSlugHistory) TableName() string {
+	return "blog_slug_histories"
+}
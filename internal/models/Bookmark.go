@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Bookmark is a blog a user saved for later. Position orders the user's bookmarks.
+type Bookmark struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_bookmark_user_blog"`
+	BlogID    uint      `gorm:"not null;uniqueIndex:idx_bookmark_user_blog;index"`
+	Position  int       `gorm:"not null;default:0"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdAt"`
+}
+
+func (Bookmark) TableName() string {
+	return "bookmarks"
+}
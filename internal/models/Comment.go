@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Comment moderation statuses
+const (
+	CommentStatusPending  = "pending"
+	CommentStatusApproved = "approved"
+	CommentStatusRejected = "rejected"
+	CommentStatusSpam     = "spam"
+)
+
+// Comment is a reader comment on a blog, optionally replying to another comment
+type Comment struct {
+	ID            uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	BlogID        uint       `gorm:"index;not null" json:"blogId"`
+	Blog          *Blog      `gorm:"foreignKey:BlogID" json:"-"`
+	AuthorID      *uint      `gorm:"index" json:"authorId"`
+	Author        *User      `gorm:"foreignKey:AuthorID" json:"author"`
+	GuestName     string     `gorm:"size:100" json:"guestName"`
+	ParentID      *uint      `gorm:"index" json:"parentId"`
+	Body          string     `gorm:"type:text;not null" json:"body"`
+	Status        string     `gorm:"size:20;not null;default:'approved';index" json:"status"`
+	EditTokenHash string     `gorm:"size:64" json:"-"`
+	IPAddress     string     `gorm:"size:45;index" json:"-"`
+	ContentHash   string     `gorm:"size:64;index" json:"-"`
+	SpamScore     float64    `gorm:"default:0" json:"spamScore"`
+	SpamReasons   string     `gorm:"type:text" json:"spamReasons"`
+	EditedAt      *time.Time `json:"editedAt"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt     time.Time  `gorm:"autoUpdateTime" json:"updatedAt"`
+	IsDeleted     bool       `gorm:"default:false" json:"isDeleted"`
+}
+
+func (Comment) TableName() string {
+	return "comments"
+}
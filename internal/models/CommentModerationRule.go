@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Comment moderation modes an author can choose for comments on their blogs
+const (
+	// ModerationModeAutoApprove publishes every comment that does not look like spam
+	ModerationModeAutoApprove = "auto_approve"
+	// ModerationModeAutoApproveTrusted publishes comments from signed-in readers the author
+	// approved before; guest comments are held for review
+	ModerationModeAutoApproveTrusted = "auto_approve_trusted"
+	// ModerationModeManual holds every comment for review
+	ModerationModeManual = "manual"
+)
+
+// CommentModerationRule is an author's auto-approve rule for comments on their blogs
+type CommentModerationRule struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	AuthorID  uint      `gorm:"uniqueIndex;not null"`
+	Mode      string    `gorm:"size:30;not null;default:'auto_approve'"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+func (CommentModerationRule) TableName() string {
+	return "comment_moderation_rules"
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Follow records that a user follows an author
+type Follow struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement"`
+	FollowerID uint      `gorm:"not null;uniqueIndex:idx_follow_follower_followee"`
+	FolloweeID uint      `gorm:"not null;uniqueIndex:idx_follow_follower_followee;index"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"createdAt"`
+}
+
+func (Follow) TableName() string {
+	return "follows"
+}
+
+// TagFollow records that a user follows a tag
+type TagFollow struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_tag_follow_user_tag"`
+	TagID     uint      `gorm:"not null;uniqueIndex:idx_tag_follow_user_tag;index"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdAt"`
+}
+
+func (TagFollow) TableName() string {
+	return "tag_follows"
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Job statuses
+const (
+	// JobStatusQueued jobs wait for their run time
+	JobStatusQueued = "queued"
+	// JobStatusRunning jobs are claimed by a worker until their lock expires
+	JobStatusRunning = "running"
+	// JobStatusSucceeded jobs are done
+	JobStatusSucceeded = "succeeded"
+	// JobStatusDead jobs ran out of attempts and wait for an administrator to retry them
+	JobStatusDead = "dead"
+)
+
+// Job is a unit of background work stored in the database, so it survives restarts
+type Job struct {
+	ID      uint   `gorm:"primaryKey;autoIncrement"`
+	Type    string `gorm:"size:80;not null;index"`
+	Payload string `gorm:"type:text;not null"`
+	// UniqueKey deduplicates jobs: a job is not enqueued while another one with the
+	// same key is queued or running
+	UniqueKey   string `gorm:"size:200;index"`
+	Status      string `gorm:"size:20;not null;index:idx_job_claim"`
+	Attempts    int    `gorm:"not null;default:0"`
+	MaxAttempts int    `gorm:"not null"`
+	// RunAt is when the job may run next
+	RunAt time.Time `gorm:"not null;index:idx_job_claim"`
+	// LockedUntil is when a running job is considered abandoned and may be claimed again
+	LockedUntil *time.Time
+	LastError   string `gorm:"size:1000"`
+	FinishedAt  *time.Time
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+func (Job) TableName() string {
+	return "jobs"
+}
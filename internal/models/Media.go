@@ -0,0 +1,65 @@
+package models
+
+import "time"
+
+// Media reference fields
+const (
+	// MediaReferenceThumbnail is a blog using the media as its thumbnail
+	MediaReferenceThumbnail = "thumbnail"
+	// MediaReferenceContent is a blog showing the media in its content
+	MediaReferenceContent = "content"
+)
+
+// Media is a file uploaded by a user to the configured storage
+type Media struct {
+	ID      uint `gorm:"primaryKey;autoIncrement"`
+	OwnerID uint `gorm:"not null;index"`
+	Owner   User `gorm:"foreignKey:OwnerID"`
+	// StorageKey locates the file in the storage
+	StorageKey   string `gorm:"size:200;not null;uniqueIndex"`
+	OriginalName string `gorm:"size:255"`
+	// ContentType is sniffed from the file rather than taken from the client
+	ContentType string `gorm:"size:50;not null"`
+	Size        int64  `gorm:"not null"`
+	// Width, Height and Placeholder, a tiny data URI version of the image, are known
+	// once the variants are generated, when ProcessedAt is set
+	Width       int
+	Height      int
+	Placeholder string `gorm:"type:text"`
+	ProcessedAt *time.Time
+	Variants    []MediaVariant   `gorm:"foreignKey:MediaID"`
+	References  []MediaReference `gorm:"foreignKey:MediaID"`
+	CreatedAt   time.Time        `gorm:"autoCreateTime" json:"createdAt"`
+}
+
+func (Media) TableName() string {
+	return "media"
+}
+
+// MediaVariant is a scaled down copy of an image, served to smaller screens
+type MediaVariant struct {
+	ID          uint   `gorm:"primaryKey;autoIncrement"`
+	MediaID     uint   `gorm:"not null;uniqueIndex:idx_media_variant"`
+	Width       int    `gorm:"not null;uniqueIndex:idx_media_variant"`
+	Height      int    `gorm:"not null"`
+	StorageKey  string `gorm:"size:200;not null;uniqueIndex"`
+	ContentType string `gorm:"size:50;not null"`
+	Size        int64  `gorm:"not null"`
+}
+
+func (MediaVariant) TableName() string {
+	return "media_variants"
+}
+
+// MediaReference records that a blog uses a media file, so files in use are not deleted
+type MediaReference struct {
+	ID      uint   `gorm:"primaryKey;autoIncrement"`
+	MediaID uint   `gorm:"not null;uniqueIndex:idx_media_reference"`
+	BlogID  uint   `gorm:"not null;uniqueIndex:idx_media_reference;index"`
+	Blog    Blog   `gorm:"foreignKey:BlogID"`
+	Field   string `gorm:"size:20;not null;uniqueIndex:idx_media_reference"`
+}
+
+func (MediaReference) TableName() string {
+	return "media_references"
+}
@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// Notification types
+const (
+	// NotificationTypeCommentPosted tells an author that a comment went live on their blog
+	NotificationTypeCommentPosted = "comment_posted"
+	// NotificationTypeCommentApproved tells a commenter that a moderator approved their comment
+	NotificationTypeCommentApproved = "comment_approved"
+	// NotificationTypeViewMilestone tells an author that their blog reached a view count milestone
+	NotificationTypeViewMilestone = "view_milestone"
+	// NotificationTypeNewPost tells followers that an author published a new blog
+	NotificationTypeNewPost = "new_post"
+)
+
+// Notification delivery channels
+const (
+	// NotificationChannelInApp stores the notification in the user's inbox
+	NotificationChannelInApp = "in_app"
+	// NotificationChannelLive pushes the notification to the user's open event streams
+	NotificationChannelLive = "live"
+)
+
+// Notification is a message in a user's in-app inbox
+type Notification struct {
+	ID        uint       `gorm:"primaryKey;autoIncrement"`
+	UserID    uint       `gorm:"not null;index:idx_notification_user_read"`
+	Type      string     `gorm:"size:40;not null"`
+	Title     string     `gorm:"size:200;not null"`
+	Message   string     `gorm:"size:500"`
+	Link      string     `gorm:"size:512"`
+	BlogID    *uint      `gorm:"index"`
+	CommentID *uint      `gorm:"index"`
+	ReadAt    *time.Time `gorm:"index:idx_notification_user_read"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"createdAt"`
+}
+
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+// NotificationPreference switches one notification type on or off for one channel.
+// Types and channels without a preference are enabled.
+type NotificationPreference struct {
+	ID      uint   `gorm:"primaryKey;autoIncrement"`
+	UserID  uint   `gorm:"not null;uniqueIndex:idx_notification_preference"`
+	Type    string `gorm:"size:40;not null;uniqueIndex:idx_notification_preference"`
+	Channel string `gorm:"size:20;not null;uniqueIndex:idx_notification_preference"`
+	Enabled bool   `gorm:"not null"`
+}
+
+func (NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
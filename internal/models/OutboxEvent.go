@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Domain events written to the outbox. They share their names with the webhook events.
+const (
+	EventBlogPublished = WebhookEventBlogPublished
+	EventBlogUpdated   = WebhookEventBlogUpdated
+	EventBlogDeleted   = WebhookEventBlogDeleted
+)
+
+// OutboxAggregateBlog marks events about a blog; AggregateID is then the blog ID
+const OutboxAggregateBlog = "blog"
+
+// OutboxEvent is a domain event recorded in the same transaction as the change that
+// caused it, and handed to the in-process handlers until all of them succeed or it
+// runs out of attempts
+type OutboxEvent struct {
+	ID            uint   `gorm:"primaryKey;autoIncrement"`
+	Type          string `gorm:"size:40;not null"`
+	AggregateType string `gorm:"size:40;not null"`
+	AggregateID   uint   `gorm:"not null"`
+	// CompletedHandlers is the comma separated list of handlers that already succeeded,
+	// so a retry only runs the ones that failed
+	CompletedHandlers string     `gorm:"type:text"`
+	Attempts          int        `gorm:"not null;default:0"`
+	LastError         string     `gorm:"size:500"`
+	NextAttemptAt     time.Time  `gorm:"not null;index:idx_outbox_event_pending"`
+	ProcessedAt       *time.Time `gorm:"index:idx_outbox_event_pending"`
+	CreatedAt         time.Time  `gorm:"autoCreateTime" json:"createdAt"`
+	// DeadAt is set when the event ran out of attempts; it is no longer dispatched
+	DeadAt *time.Time `gorm:"index"`
+	// Payload is a JSON snapshot of the aggregate, for events about one that no longer
+	// exists, like a blog deleted for good
+	Payload string `gorm:"type:text"`
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Reaction is a reader's reaction of a given type on a blog. A reader is either a
+// user or an anonymous visitor, identified together by ReactorKey.
+type Reaction struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement"`
+	BlogID     uint      `gorm:"not null;uniqueIndex:idx_reaction_blog_reactor_type"`
+	UserID     *uint     `gorm:"index"`
+	VisitorID  string    `gorm:"size:64"`
+	ReactorKey string    `gorm:"size:80;not null;uniqueIndex:idx_reaction_blog_reactor_type"`
+	Type       string    `gorm:"size:30;not null;uniqueIndex:idx_reaction_blog_reactor_type"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"createdAt"`
+}
+
+func (Reaction) TableName() string {
+	return "reactions"
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// ReadingList is a named, ordered collection of blogs kept by a user
+type ReadingList struct {
+	ID        uint              `gorm:"primaryKey;autoIncrement"`
+	UserID    uint              `gorm:"not null;uniqueIndex:idx_reading_list_user_name"`
+	Name      string            `gorm:"size:100;not null;uniqueIndex:idx_reading_list_user_name"`
+	Items     []ReadingListItem `gorm:"foreignKey:ReadingListID;constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time         `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt time.Time         `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+func (ReadingList) TableName() string {
+	return "reading_lists"
+}
+
+// ReadingListItem places a blog in a reading list. Position orders the list.
+type ReadingListItem struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement"`
+	ReadingListID uint      `gorm:"not null;uniqueIndex:idx_reading_list_item_blog"`
+	BlogID        uint      `gorm:"not null;uniqueIndex:idx_reading_list_item_blog;index"`
+	Position      int       `gorm:"not null;default:0"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"createdAt"`
+}
+
+func (ReadingListItem) TableName() string {
+	return "reading_list_items"
+}
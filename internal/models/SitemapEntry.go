@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Sitemap entry kinds
+const (
+	SitemapKindPost     = "post"
+	SitemapKindCategory = "category"
+	SitemapKindAuthor   = "author"
+)
+
+// SitemapEntry is one URL of the sitemap. Entries are kept up to date as posts change,
+// so the sitemap is rendered without scanning every blog.
+type SitemapEntry struct {
+	ID    uint   `gorm:"primaryKey;autoIncrement"`
+	Kind  string `gorm:"size:20;not null;uniqueIndex:idx_sitemap_entry_ref"`
+	RefID uint   `gorm:"not null;uniqueIndex:idx_sitemap_entry_ref"`
+	// Path is the page's path on the site, e.g. /@author/slug
+	Path    string    `gorm:"size:600;not null"`
+	LastMod time.Time `gorm:"not null"`
+}
+
+func (SitemapEntry) TableName() string {
+	return "sitemap_entries"
+}
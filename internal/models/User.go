@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type User struct {
 	Email             string `gorm:"size:64;not null;unique"`
@@ -22,3 +26,20 @@ type User struct {
 func (User) TableName() string {
 	return "users"
 }
+
+// BeforeUpdate is a GORM hook that records the previous user name when it changes,
+// so links to the author's old blog URLs can be redirected
+func (u *User) BeforeUpdate(tx *gorm.DB) (err error) {
+	if u.ID == 0 || u.UserName == "" {
+		return
+	}
+	db := tx.Session(&gorm.Session{NewDB: true})
+	var previous string
+	if err = db.Model(&User{}).Select("user_name").Where("id = ?", u.ID).Scan(&previous).Error; err != nil {
+		return
+	}
+	if previous == "" || previous == u.UserName {
+		return
+	}
+	return db.Create(&UserNameHistory{UserID: u.ID, UserName: previous}).Error
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// UserNameHistory keeps the user names an author was previously known by
+type UserNameHistory struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	UserID    uint      `gorm:"index;not null"`
+	UserName  string    `gorm:"type:text;not null;index"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdAt"`
+}
+
+func (UserNameHistory) TableName() string {
+	return "user_name_histories"
+}
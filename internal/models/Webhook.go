@@ -0,0 +1,78 @@
+package models
+
+import "time"
+
+// Webhook event types
+const (
+	// WebhookEventBlogPublished is sent when a blog goes live, on creation or when a draft is published
+	WebhookEventBlogPublished = "blog.published"
+	// WebhookEventBlogUpdated is sent when a published blog is edited or unpublished
+	WebhookEventBlogUpdated = "blog.updated"
+	// WebhookEventBlogDeleted is sent when a blog is deleted
+	WebhookEventBlogDeleted = "blog.deleted"
+)
+
+// Webhook delivery statuses
+const (
+	// WebhookDeliveryStatusPending deliveries are waiting for their next attempt
+	WebhookDeliveryStatusPending = "pending"
+	// WebhookDeliveryStatusSucceeded deliveries were accepted by the receiver
+	WebhookDeliveryStatusSucceeded = "succeeded"
+	// WebhookDeliveryStatusFailed deliveries ran out of attempts
+	WebhookDeliveryStatusFailed = "failed"
+)
+
+// Webhook is a subscription of a downstream system to content events
+type Webhook struct {
+	ID          uint   `gorm:"primaryKey;autoIncrement"`
+	URL         string `gorm:"size:500;not null"`
+	Description string `gorm:"size:200"`
+	// Secret signs the payloads so the receiver can verify they come from us
+	Secret string `gorm:"size:128;not null"`
+	// Events is the comma separated list of event types the webhook receives
+	Events    string    `gorm:"size:200;not null"`
+	Active    bool      `gorm:"not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// WebhookDelivery is one event to deliver to one webhook, retried until it succeeds
+// or runs out of attempts
+type WebhookDelivery struct {
+	ID             uint       `gorm:"primaryKey;autoIncrement"`
+	WebhookID      uint       `gorm:"not null;index"`
+	Event          string     `gorm:"size:40;not null"`
+	Payload        string     `gorm:"type:text;not null"`
+	Status         string     `gorm:"size:20;not null;index:idx_webhook_delivery_due"`
+	Attempts       int        `gorm:"not null;default:0"`
+	NextAttemptAt  *time.Time `gorm:"index:idx_webhook_delivery_due"`
+	LastStatusCode int
+	LastError      string `gorm:"size:500"`
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+
+	AttemptLog []WebhookDeliveryAttempt `gorm:"foreignKey:DeliveryID"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// WebhookDeliveryAttempt records the outcome of one request made for a delivery
+type WebhookDeliveryAttempt struct {
+	ID         uint `gorm:"primaryKey;autoIncrement"`
+	DeliveryID uint `gorm:"not null;index"`
+	StatusCode int
+	Error      string    `gorm:"size:500"`
+	DurationMs int64     `gorm:"not null"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"createdAt"`
+}
+
+func (WebhookDeliveryAttempt) TableName() string {
+	return "webhook_delivery_attempts"
+}
@@ -0,0 +1,112 @@
+package pubsub
+
+import (
+	"sync"
+)
+
+// Event is a message published on the bus. It is delivered to every subscriber of
+// at least one of its topics.
+type Event struct {
+	Type   string
+	Topics []string
+	Data   interface{}
+}
+
+// Bus is an in-process publish/subscribe hub. Publishing never blocks: a subscriber
+// that falls behind by more than its buffer is dropped, and its event channel closed,
+// so one slow client cannot hold back the others.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[*Subscription]struct{}
+}
+
+// Subscription receives the events of a set of topics
+type Subscription struct {
+	topics     map[string]bool
+	events     chan Event
+	closeOnce  sync.Once
+	overflowed bool
+}
+
+// NewBus creates an empty Bus
+func NewBus() *Bus {
+	return &Bus{subscribers: make(map[*Subscription]struct{})}
+}
+
+// Subscribe registers a subscriber for the given topics, buffering up to buffer events
+func (b *Bus) Subscribe(topics []string, buffer int) *Subscription {
+	subscription := &Subscription{
+		topics: make(map[string]bool, len(topics)),
+		events: make(chan Event, buffer),
+	}
+	for _, topic := range topics {
+		subscription.topics[topic] = true
+	}
+
+	b.mu.Lock()
+	b.subscribers[subscription] = struct{}{}
+	b.mu.Unlock()
+	return subscription
+}
+
+// Unsubscribe removes a subscriber and closes its event channel. It is safe to call more than once.
+func (b *Bus) Unsubscribe(subscription *Subscription) {
+	b.mu.Lock()
+	delete(b.subscribers, subscription)
+	b.mu.Unlock()
+	subscription.closeOnce.Do(func() { close(subscription.events) })
+}
+
+// Publish delivers an event to the subscribers of its topics without waiting for them
+func (b *Bus) Publish(event Event) {
+	var overflowed []*Subscription
+
+	b.mu.RLock()
+	for subscription := range b.subscribers {
+		if !subscription.matches(event) {
+			continue
+		}
+		select {
+		case subscription.events <- event:
+		default:
+			overflowed = append(overflowed, subscription)
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, subscription := range overflowed {
+		b.drop(subscription)
+	}
+}
+
+// drop removes a subscriber that fell behind
+func (b *Bus) drop(subscription *Subscription) {
+	b.mu.Lock()
+	if _, ok := b.subscribers[subscription]; ok {
+		subscription.overflowed = true
+		delete(b.subscribers, subscription)
+	}
+	b.mu.Unlock()
+	subscription.closeOnce.Do(func() { close(subscription.events) })
+}
+
+// Events returns the channel events are delivered on. It is closed when the
+// subscriber is removed from the bus.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Overflowed reports whether the subscriber was dropped for not keeping up.
+// It is only meaningful once the event channel is closed.
+func (s *Subscription) Overflowed() bool {
+	return s.overflowed
+}
+
+func (s *Subscription) matches(event Event) bool {
+	for _, topic := range event.Topics {
+		if s.topics[topic] {
+			return true
+		}
+	}
+	return false
+}
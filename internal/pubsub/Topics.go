@@ -0,0 +1,47 @@
+package pubsub
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TopicPosts carries every newly published blog
+const TopicPosts = "posts"
+
+// BlogTopic carries the comments and view counts of a single blog. Slugs are only
+// unique per author, so the topic is named after both, as in the blog's URL.
+func BlogTopic(username string, slug string) string {
+	return "blog:" + username + "/" + slug
+}
+
+// CategoryTopic carries the blogs published in a category
+func CategoryTopic(slug string) string {
+	return "category:" + slug
+}
+
+// AuthorTopic carries the blogs published by an author
+func AuthorTopic(username string) string {
+	return "author:" + username
+}
+
+// UserTopic carries the live notifications of a user. It is private to that user.
+func UserTopic(userId uint) string {
+	return fmt.Sprintf("user:%d", userId)
+}
+
+// IsPublicTopic reports whether clients may subscribe to topic without signing in
+func IsPublicTopic(topic string) bool {
+	if topic == TopicPosts {
+		return true
+	}
+	if name, found := strings.CutPrefix(topic, "blog:"); found {
+		username, slug, found := strings.Cut(name, "/")
+		return found && username != "" && slug != ""
+	}
+	for _, prefix := range []string{"category:", "author:"} {
+		if name, found := strings.CutPrefix(topic, prefix); found {
+			return name != ""
+		}
+	}
+	return false
+}
@@ -3,6 +3,8 @@ package repositories
 import (
 	"errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
 	"yp-blog-api/internal/models"
 )
 
@@ -19,26 +21,150 @@ func NewAdvertisingBannerRepository(db *gorm.DB) *AdvertisingBannerRepository {
 // FindAllByIsDeletedIsFalse retrieves all non-deleted advertising banners
 func (r *AdvertisingBannerRepository) FindAllByIsDeletedIsFalse() ([]models.AdvertisingBanner, error) {
 	var banners []models.AdvertisingBanner
-	err := r.db.Where("is_deleted = ?", false).Find(&banners).Error
+	err := r.db.Preload("Categories").Where("is_deleted = ?", false).Find(&banners).Error
 	if err != nil {
 		return nil, err
 	}
 	return banners, nil
 }
 
+// FindAllActive retrieves the banners to show at the given time: not deleted, within their
+// campaign window and below their daily impression cap, by descending priority
+func (r *AdvertisingBannerRepository) FindAllActive(now time.Time) ([]models.AdvertisingBanner, error) {
+	var banners []models.AdvertisingBanner
+	// Campaign times are stored in UTC and SQLite compares them as text, so the bound
+	// time must be in UTC as well
+	err := r.db.Preload("Categories").
+		Where("is_deleted = ?", false).
+		Where("starts_at IS NULL OR starts_at <= ?", now.UTC()).
+		Where("ends_at IS NULL OR ends_at > ?", now.UTC()).
+		Order("priority DESC, id").
+		Find(&banners).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// The day a cap applies to depends on each banner's time zone, so caps are checked here
+	impressions, err := r.FindImpressionsOnDay(banners, now)
+	if err != nil {
+		return nil, err
+	}
+	active := banners[:0]
+	for _, banner := range banners {
+		if banner.DailyImpressionCap == 0 || impressions[banner.ID] < banner.DailyImpressionCap {
+			active = append(active, banner)
+		}
+	}
+	return active, nil
+}
+
+// FindImpressionsOnDay counts how often each banner was shown on the day, in the
+// banner's time zone, the given time falls on
+func (r *AdvertisingBannerRepository) FindImpressionsOnDay(banners []models.AdvertisingBanner, now time.Time) (map[int64]int64, error) {
+	impressions := make(map[int64]int64)
+	if len(banners) == 0 {
+		return impressions, nil
+	}
+	ids := make([]int64, len(banners))
+	days := make([]string, len(banners))
+	for i, banner := range banners {
+		ids[i] = banner.ID
+		days[i] = banner.Day(now)
+	}
+	var stats []models.BannerDailyStat
+	if err := r.db.Where("banner_id IN ? AND day IN ?", ids, days).Find(&stats).Error; err != nil {
+		return nil, err
+	}
+	for i, banner := range banners {
+		for _, stat := range stats {
+			if stat.BannerID == banner.ID && stat.Day == days[i] {
+				impressions[banner.ID] = stat.Impressions
+			}
+		}
+	}
+	return impressions, nil
+}
+
+// IncrementImpressions counts one impression of each banner on its current day. Banners
+// shown concurrently may overshoot their cap slightly.
+func (r *AdvertisingBannerRepository) IncrementImpressions(banners []models.AdvertisingBanner, now time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, banner := range banners {
+			if err := incrementBannerStat(tx, banner, now, "impressions"); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// IncrementClicks counts one click of the banner on its current day
+func (r *AdvertisingBannerRepository) IncrementClicks(banner models.AdvertisingBanner, now time.Time) error {
+	return incrementBannerStat(r.db, banner, now, "clicks")
+}
+
+// incrementBannerStat adds one to a counter of the banner's daily stats, creating them on
+// the first count of the day
+func incrementBannerStat(db *gorm.DB, banner models.AdvertisingBanner, now time.Time, column string) error {
+	stat := models.BannerDailyStat{BannerID: banner.ID, Day: banner.Day(now)}
+	if column == "clicks" {
+		stat.Clicks = 1
+	} else {
+		stat.Impressions = 1
+	}
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "banner_id"}, {Name: "day"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{column: gorm.Expr("banner_daily_stats." + column + " + 1")}),
+	}).Create(&stat).Error
+}
+
+// FindDailyStats retrieves the daily stats of a banner from one day to another, both
+// included, oldest first; days without impressions or clicks have no stats
+func (r *AdvertisingBannerRepository) FindDailyStats(bannerId int64, from string, to string) ([]models.BannerDailyStat, error) {
+	var stats []models.BannerDailyStat
+	err := r.db.Where("banner_id = ? AND day >= ? AND day <= ?", bannerId, from, to).
+		Order("day").
+		Find(&stats).Error
+	return stats, err
+}
+
+// SumStatsByBanner totals the impressions and clicks of every banner from one day to
+// another, both included; the day of the returned stats is left empty
+func (r *AdvertisingBannerRepository) SumStatsByBanner(from string, to string) ([]models.BannerDailyStat, error) {
+	var stats []models.BannerDailyStat
+	err := r.db.Model(&models.BannerDailyStat{}).
+		Select("banner_id, SUM(impressions) AS impressions, SUM(clicks) AS clicks").
+		Where("day >= ? AND day <= ?", from, to).
+		Group("banner_id").
+		Find(&stats).Error
+	return stats, err
+}
+
+// FindAll retrieves all advertising banners, deleted ones included, newest first
+func (r *AdvertisingBannerRepository) FindAll() ([]models.AdvertisingBanner, error) {
+	var banners []models.AdvertisingBanner
+	err := r.db.Preload("Categories").Order("id DESC").Find(&banners).Error
+	return banners, err
+}
+
 // FindById retrieves an advertising banner by ID
 func (r *AdvertisingBannerRepository) FindById(id int64) (*models.AdvertisingBanner, error) {
 	var banner models.AdvertisingBanner
-	err := r.db.First(&banner, id).Error
+	err := r.db.Preload("Categories").First(&banner, id).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
 	return &banner, err
 }
 
-// Save saves or updates an advertising banner
+// Save saves or updates an advertising banner, replacing the categories it targets
 func (r *AdvertisingBannerRepository) Save(banner *models.AdvertisingBanner) error {
-	return r.db.Save(banner).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Categories").Save(banner).Error; err != nil {
+			return err
+		}
+		return tx.Model(banner).Association("Categories").Replace(banner.Categories)
+	})
 }
 
 // Delete removes an advertising banner from the database
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+	"yp-blog-api/internal/models"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	_ "modernc.org/sqlite"
+)
+
+func newBannerTestRepository(t *testing.T) *AdvertisingBannerRepository {
+	db, err := gorm.Open(sqlite.Dialector{DriverName: "sqlite", DSN: "file::memory:"}, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("Failed to open the database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("Failed to open the database: %v", err)
+	}
+	// Every connection to :memory: is a database of its own
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+	if err := db.AutoMigrate(&models.Category{}, &models.AdvertisingBanner{}, &models.BannerDailyStat{}); err != nil {
+		t.Fatalf("Failed to migrate the database: %v", err)
+	}
+	return NewAdvertisingBannerRepository(db)
+}
+
+func TestFindAllActiveOutsideUTC(t *testing.T) {
+	location, err := time.LoadLocation("Asia/Phnom_Penh")
+	if err != nil {
+		t.Skipf("Time zone data is not available: %v", err)
+	}
+	local := time.Local
+	time.Local = location
+	t.Cleanup(func() { time.Local = local })
+
+	repo := newBannerTestRepository(t)
+	now := time.Now()
+	hour := func(n int) *time.Time {
+		// Campaign times are saved in UTC, as the banner service does
+		t := now.Add(time.Duration(n) * time.Hour).UTC()
+		return &t
+	}
+	banners := []models.AdvertisingBanner{
+		{Title: "running", StartsAt: hour(-3), EndsAt: hour(3)},
+		{Title: "scheduled", StartsAt: hour(3)},
+		{Title: "ended", EndsAt: hour(-3)},
+		{Title: "open"},
+	}
+	for i := range banners {
+		if err := repo.Save(&banners[i]); err != nil {
+			t.Fatalf("Failed to save banner %q: %v", banners[i].Title, err)
+		}
+	}
+
+	active, err := repo.FindAllActive(now)
+	if err != nil {
+		t.Fatalf("FindAllActive failed: %v", err)
+	}
+	titles := make(map[string]bool)
+	for _, banner := range active {
+		titles[banner.Title] = true
+	}
+	if len(active) != 2 || !titles["running"] || !titles["open"] {
+		t.Errorf("FindAllActive returned %v, want running and open", titles)
+	}
+}
@@ -1,11 +1,29 @@
 package repositories
 
 import (
+	"errors"
 	"time"
 	"yp-blog-api/internal/dto"
 	"yp-blog-api/internal/models"
 )
 
+// ErrSlugTaken is returned when saving a blog violates the unique slug constraint
+var ErrSlugTaken = errors.New("slug already taken")
+
+// FeedCursor is the position of the last blog of a feed page. Feeds are ordered by
+// creation time and id, both descending, so the next page starts strictly after it.
+type FeedCursor struct {
+	CreatedAt time.Time
+	ID        uint
+}
+
+// BlogFilter narrows a blog listing down to a category, a tag and/or an author; zero values match everything
+type BlogFilter struct {
+	CategorySlug string
+	TagID        uint
+	AuthorID     uint
+}
+
 type BlogRepository interface {
 	FindBlogsByCategorySlug(categorySlug string) ([]models.Blog, error)
 	FindAllByPublishedAndNotDeletedOrderByCountViewerDescCreatedAtDesc() ([]models.Blog, error)
@@ -13,15 +31,27 @@ type BlogRepository interface {
 	FindRandom6ByUsername(username string) ([]models.Blog, error)
 	FindTop6ByCategorySlug(categorySlug string) ([]models.Blog, error)
 	FindByUsernameAndSlug(username, slug string) (models.Blog, error)
+	FindByPreviousUsernameOrSlug(username, slug string) (models.Blog, error)
+	FindRelatedCandidates(blog models.Blog, limit int) ([]models.Blog, error)
 	FindTopAuthors(startDate time.Time, limit int) ([]map[string]interface{}, error)
 	CountPinnedBlogsByAuthorId(authorId uint) (int64, error)
 	FindAllByAuthorNameOrderByPinnedAndCreatedAtAndCountViewer(authorName string) ([]models.Blog, error)
 	CountByAuthorEmailIgnoreCase(authorEmail string) (int64, error)
+	ExistsBySlug(slug string, authorId uint, excludeBlogId uint) (bool, error)
+	FindFeedPage(userId uint, before *FeedCursor, limit int) ([]models.Blog, error)
+	IncrementCountViewer(id uint, delta int) (models.Blog, error)
+	FindByIdWithAssociations(id uint) (models.Blog, error)
+	// FindByOutboxEvent retrieves the blog an outbox event is about, from the snapshot
+	// the event carries when the blog was deleted for good
+	FindByOutboxEvent(event models.OutboxEvent) (models.Blog, error)
+	FindLatestPublished(filter BlogFilter, limit int) ([]models.Blog, error)
 
 	Save(blog models.Blog) (models.Blog, error)
+	SaveWithEvents(blog models.Blog, events []models.OutboxEvent) (models.Blog, error)
 	FindById(id uint) (models.Blog, error)
 	FindAll() ([]models.Blog, error)
-	FindBySlug(slug string) (models.Blog, error)
 	Update(blog models.Blog) (models.Blog, error)
+	// DeleteById deletes a blog for good. Its pending outbox events are dropped, and
+	// subscribers are told about the deletion when the blog was public.
 	DeleteById(id uint) error
 }
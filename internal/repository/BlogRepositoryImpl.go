@@ -1,9 +1,11 @@
 package repositories
 
 import (
+	"encoding/json"
 	"errors"
 	"gorm.io/gorm"
 	"log"
+	"strings"
 	"time"
 	"yp-blog-api/internal/dto"
 	mapper "yp-blog-api/internal/mapping"
@@ -90,12 +92,50 @@ func (r *blogRepositoryImpl) FindTop6ByCategorySlug(categorySlug string) ([]mode
 func (r *blogRepositoryImpl) FindByUsernameAndSlug(username, slug string) (models.Blog, error) {
 	var blog models.Blog
 	err := r.db.Preload("Author"). // Preloads the related Author (User) model
+					Preload("Tags").
+					Preload("Categories").
 					Joins("Author").
 					Where("Author.user_name = ? AND blogs.slug = ?", username, slug).
 					First(&blog).Error
 	return blog, err
 }
 
+// FindByPreviousUsernameOrSlug finds a published blog requested through an old author
+// name and/or an old slug, using the user name and slug history tables.
+func (r *blogRepositoryImpl) FindByPreviousUsernameOrSlug(username, slug string) (models.Blog, error) {
+	var blog models.Blog
+	currentAuthors := r.db.Table("users").Select("id").Where("user_name = ?", username)
+	previousAuthors := r.db.Table("user_name_histories").Select("user_id").Where("user_name = ?", username)
+	previousSlugs := r.db.Table("blog_slug_histories").Select("blog_id").Where("slug = ?", slug)
+	// Drafts and deleted blogs are not public, so neither is where they moved
+	err := r.db.Preload("Author").
+		Where("blogs.published = ? AND blogs.is_deleted = ?", true, false).
+		Where("blogs.author_id IN (?) OR blogs.author_id IN (?)", currentAuthors, previousAuthors).
+		Where("blogs.slug = ? OR blogs.id IN (?)", slug, previousSlugs).
+		Order("blogs.updated_at DESC").
+		First(&blog).Error
+	return blog, err
+}
+
+// FindRelatedCandidates retrieves published blogs sharing at least one tag or
+// category with the given blog, most recent first, excluding the blog itself.
+func (r *blogRepositoryImpl) FindRelatedCandidates(blog models.Blog, limit int) ([]models.Blog, error) {
+	var blogs []models.Blog
+	sharedTags := r.db.Table("blog_tags").Select("blog_id").
+		Where("tag_id IN (?)", r.db.Table("blog_tags").Select("tag_id").Where("blog_id = ?", blog.ID))
+	sharedCategories := r.db.Table("blog_categories").Select("blog_id").
+		Where("category_id IN (?)", r.db.Table("blog_categories").Select("category_id").Where("blog_id = ?", blog.ID))
+	err := r.db.Preload("Author").
+		Preload("Tags").
+		Preload("Categories").
+		Where("blogs.id <> ? AND blogs.published = ? AND blogs.is_deleted = ?", blog.ID, true, false).
+		Where("blogs.id IN (?) OR blogs.id IN (?)", sharedTags, sharedCategories).
+		Order("blogs.created_at DESC").
+		Limit(limit).
+		Find(&blogs).Error
+	return blogs, err
+}
+
 func (r *blogRepositoryImpl) FindTopAuthors(startDate time.Time, limit int) ([]map[string]interface{}, error) {
 	var results []map[string]interface{}
 	err := r.db.Table("blogs b").
@@ -135,10 +175,117 @@ func (r *blogRepositoryImpl) CountByAuthorEmailIgnoreCase(authorEmail string) (i
 	return count, err
 }
 
-func (r *blogRepositoryImpl) Save(blog models.Blog) (models.Blog, error) {
-	if err := r.db.Save(&blog).Error; err != nil {
+// ExistsBySlug reports whether a slug is used by another blog of the same author, either
+// as its current slug or as a previous one that still redirects to it.
+func (r *blogRepositoryImpl) ExistsBySlug(slug string, authorId uint, excludeBlogId uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Blog{}).
+		Where("slug = ? AND author_id = ? AND id <> ?", slug, authorId, excludeBlogId).
+		Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+	err = r.db.Table("blog_slug_histories h").
+		Joins("JOIN blogs b ON b.id = h.blog_id").
+		Where("h.slug = ? AND b.author_id = ? AND h.blog_id <> ?", slug, authorId, excludeBlogId).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FindFeedPage lists published blogs by the authors and tags a user follows, newest
+// first, starting after the before cursor when it is set
+func (r *blogRepositoryImpl) FindFeedPage(userId uint, before *FeedCursor, limit int) ([]models.Blog, error) {
+	query := r.db.Preload("Author").
+		Where("blogs.published = ? AND blogs.is_deleted = false", true).
+		Where(r.db.Where("blogs.author_id IN (?)", r.db.Model(&models.Follow{}).Select("followee_id").Where("follower_id = ?", userId)).
+			Or("blogs.id IN (?)", r.db.Table("blog_tags bt").Select("bt.blog_id").
+				Joins("JOIN tag_follows tf ON tf.tag_id = bt.tag_id").
+				Where("tf.user_id = ?", userId)))
+	if before != nil {
+		query = query.Where("blogs.created_at < ? OR (blogs.created_at = ? AND blogs.id < ?)",
+			before.CreatedAt, before.CreatedAt, before.ID)
+	}
+
+	var blogs []models.Blog
+	err := query.Order("blogs.created_at DESC, blogs.id DESC").Limit(limit).Find(&blogs).Error
+	return blogs, err
+}
+
+// IncrementCountViewer adds delta to a blog's view count and returns the updated blog.
+// The update skips hooks and timestamps since views do not modify the blog.
+func (r *blogRepositoryImpl) IncrementCountViewer(id uint, delta int) (models.Blog, error) {
+	err := r.db.Model(&models.Blog{}).Where("id = ?", id).
+		UpdateColumn("count_viewer", gorm.Expr("COALESCE(count_viewer, 0) + ?", delta)).Error
+	if err != nil {
 		return models.Blog{}, err
 	}
+	var blog models.Blog
+	err = r.db.Preload("Author").First(&blog, id).Error
+	return blog, err
+}
+
+// FindLatestPublished retrieves the newest published, non-deleted blogs matching the
+// filter, with their author, categories and tags
+func (r *blogRepositoryImpl) FindLatestPublished(filter BlogFilter, limit int) ([]models.Blog, error) {
+	query := r.db.Preload("Author").
+		Preload("Categories").
+		Preload("Tags").
+		Where("blogs.published = ? AND blogs.is_deleted IS FALSE", true)
+	if filter.CategorySlug != "" {
+		query = query.Where("blogs.id IN (?)", r.db.Table("blog_categories bc").Select("bc.blog_id").
+			Joins("JOIN categories c ON bc.category_id = c.id").
+			Where("c.slug = ?", filter.CategorySlug))
+	}
+	if filter.TagID != 0 {
+		query = query.Where("blogs.id IN (?)", r.db.Table("blog_tags bt").Select("bt.blog_id").Where("bt.tag_id = ?", filter.TagID))
+	}
+	if filter.AuthorID != 0 {
+		query = query.Where("blogs.author_id = ?", filter.AuthorID)
+	}
+
+	var blogs []models.Blog
+	err := query.Order("blogs.created_at DESC, blogs.id DESC").Limit(limit).Find(&blogs).Error
+	return blogs, err
+}
+
+// FindByIdWithAssociations retrieves a blog together with its author, categories and tags
+func (r *blogRepositoryImpl) FindByIdWithAssociations(id uint) (models.Blog, error) {
+	var blog models.Blog
+	err := r.db.Preload("Author").
+		Preload("Categories").
+		Preload("Tags").
+		First(&blog, id).Error
+	return blog, err
+}
+
+func (r *blogRepositoryImpl) FindByOutboxEvent(event models.OutboxEvent) (models.Blog, error) {
+	if event.Payload == "" {
+		return r.FindByIdWithAssociations(event.AggregateID)
+	}
+	var blog models.Blog
+	err := json.Unmarshal([]byte(event.Payload), &blog)
+	return blog, err
+}
+
+func (r *blogRepositoryImpl) Save(blog models.Blog) (models.Blog, error) {
+	if err := saveBlog(r.db, &blog); err != nil {
+		return models.Blog{}, translateSlugError(err)
+	}
+	return blog, nil
+}
+
+// SaveWithEvents saves a blog and records the events about it in the outbox, in one
+// transaction, so the events exist if and only if the change was committed
+func (r *blogRepositoryImpl) SaveWithEvents(blog models.Blog, events []models.OutboxEvent) (models.Blog, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := saveBlog(tx, &blog); err != nil {
+			return err
+		}
+		return createOutboxEvents(tx, blog.ID, events)
+	})
+	if err != nil {
+		return models.Blog{}, translateSlugError(err)
+	}
 	return blog, nil
 }
 
@@ -161,27 +308,62 @@ func (r *blogRepositoryImpl) FindAll() ([]models.Blog, error) {
 	return blogs, nil
 }
 
-func (r *blogRepositoryImpl) FindBySlug(slug string) (models.Blog, error) {
-	var blog models.Blog
-	if err := r.db.Where("slug = ?", slug).First(&blog).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.Blog{}, errors.New("blog not found")
-		}
-		return models.Blog{}, err
+func (r *blogRepositoryImpl) Update(blog models.Blog) (models.Blog, error) {
+	if err := saveBlog(r.db, &blog); err != nil {
+		return models.Blog{}, translateSlugError(err)
 	}
 	return blog, nil
 }
 
-func (r *blogRepositoryImpl) Update(blog models.Blog) (models.Blog, error) {
-	if err := r.db.Save(&blog).Error; err != nil {
-		return models.Blog{}, err
+// saveBlog saves a blog, leaving the view count of an existing blog as it is in the
+// database: views are only added with IncrementCountViewer, and the count loaded with
+// the blog may be stale by the time it is saved
+func saveBlog(db *gorm.DB, blog *models.Blog) error {
+	if blog.ID != 0 {
+		db = db.Omit("CountViewer")
 	}
-	return blog, nil
+	return db.Save(blog).Error
 }
 
-func (r *blogRepositoryImpl) DeleteById(id uint) error {
-	if err := r.db.Delete(&models.Blog{}, id).Error; err != nil {
-		return err
+// translateSlugError maps a violation of the unique (author, slug) index to ErrSlugTaken
+func translateSlugError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) || strings.Contains(err.Error(), "UNIQUE constraint failed: blogs.slug") {
+		return ErrSlugTaken
 	}
-	return nil
+	return err
+}
+
+func (r *blogRepositoryImpl) DeleteById(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var blog models.Blog
+		err := tx.Preload("Author").Preload("Categories").Preload("Tags").First(&blog, id).Error
+		if err != nil {
+			return err
+		}
+		// Handlers of the pending events would look for a blog that is gone
+		if err := tx.Where("aggregate_type = ? AND aggregate_id = ? AND processed_at IS NULL", models.OutboxAggregateBlog, id).
+			Delete(&models.OutboxEvent{}).Error; err != nil {
+			return err
+		}
+		if blog.Published && !blog.IsDeleted {
+			payload, err := deletedBlogSnapshot(blog)
+			if err != nil {
+				return err
+			}
+			if err := createOutboxEvents(tx, blog.ID, []models.OutboxEvent{{Type: models.EventBlogDeleted, Payload: payload}}); err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&models.Blog{}, id).Error
+	})
+}
+
+// deletedBlogSnapshot keeps what the handlers of a deletion need to know about a blog,
+// leaving out its content and its author's account details
+func deletedBlogSnapshot(blog models.Blog) (string, error) {
+	blog.IsDeleted = true
+	blog.BlogContent = ""
+	blog.Author = models.User{ID: blog.Author.ID, UserName: blog.Author.UserName}
+	payload, err := json.Marshal(blog)
+	return string(payload), err
 }
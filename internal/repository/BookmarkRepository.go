@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"yp-blog-api/internal/models"
+)
+
+type BookmarkRepository interface {
+	CreateIfNotExists(bookmark *models.Bookmark) error
+	DeleteByUserIdAndBlogId(userId uint, blogId uint) error
+	FindBlogsByUserId(userId uint) ([]models.Blog, error)
+	FindBookmarkedBlogIds(userId uint, blogIds []uint) (map[uint]bool, error)
+	Reorder(userId uint, blogIds []uint) error
+}
+
+type bookmarkRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewBookmarkRepository(db *gorm.DB) BookmarkRepository {
+	return &bookmarkRepositoryImpl{db: db}
+}
+
+// CreateIfNotExists appends a bookmark after the user's existing ones, doing nothing
+// when the blog is already bookmarked
+func (r *bookmarkRepositoryImpl) CreateIfNotExists(bookmark *models.Bookmark) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var last int
+		err := tx.Model(&models.Bookmark{}).
+			Select("COALESCE(MAX(position), 0)").
+			Where("user_id = ?", bookmark.UserID).
+			Scan(&last).Error
+		if err != nil {
+			return err
+		}
+		bookmark.Position = last + 1
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(bookmark).Error
+	})
+}
+
+func (r *bookmarkRepositoryImpl) DeleteByUserIdAndBlogId(userId uint, blogId uint) error {
+	return r.db.Where("user_id = ? AND blog_id = ?", userId, blogId).Delete(&models.Bookmark{}).Error
+}
+
+// FindBlogsByUserId lists the visible blogs a user bookmarked in bookmark order
+func (r *bookmarkRepositoryImpl) FindBlogsByUserId(userId uint) ([]models.Blog, error) {
+	var blogs []models.Blog
+	err := r.db.Preload("Author").
+		Joins("JOIN bookmarks bm ON bm.blog_id = blogs.id").
+		Where("bm.user_id = ? AND blogs.published = ? AND blogs.is_deleted = false", userId, true).
+		Order("bm.position, bm.id").
+		Find(&blogs).Error
+	return blogs, err
+}
+
+// FindBookmarkedBlogIds reports which of the given blogs the user bookmarked
+func (r *bookmarkRepositoryImpl) FindBookmarkedBlogIds(userId uint, blogIds []uint) (map[uint]bool, error) {
+	bookmarked := make(map[uint]bool)
+	if len(blogIds) == 0 {
+		return bookmarked, nil
+	}
+	var ids []uint
+	err := r.db.Model(&models.Bookmark{}).
+		Where("user_id = ? AND blog_id IN ?", userId, blogIds).
+		Pluck("blog_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		bookmarked[id] = true
+	}
+	return bookmarked, nil
+}
+
+// Reorder moves the given blogs to the front of the user's bookmarks in the given order
+func (r *bookmarkRepositoryImpl) Reorder(userId uint, blogIds []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return reorderPositions(tx.Model(&models.Bookmark{}).Where("user_id = ?", userId), blogIds)
+	})
+}
+
+// reorderPositions renumbers the rows selected by scope so the given blogs come first,
+// in the given order, followed by the remaining rows in their current order
+func reorderPositions(scope *gorm.DB, blogIds []uint) error {
+	var current []uint
+	if err := scope.Session(&gorm.Session{}).Order("position, id").Pluck("blog_id", &current).Error; err != nil {
+		return err
+	}
+
+	ordered := make([]uint, 0, len(current))
+	seen := make(map[uint]bool, len(current))
+	for _, id := range append(append([]uint{}, blogIds...), current...) {
+		if !seen[id] {
+			seen[id] = true
+			ordered = append(ordered, id)
+		}
+	}
+	for i, id := range ordered {
+		err := scope.Session(&gorm.Session{}).Where("blog_id = ?", id).Update("position", i+1).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ type CategoryRepository interface {
 	FindAllById(ids []int) ([]models.Category, error)
 	FindTopCategoriesByBlogCount() ([]models.Category, error)
 	FindBySlug(slug string) (*models.Category, error)
+	FindAllBySlug(slugs []string) ([]models.Category, error)
 }
 
 type categoryRepositoryImpl struct {
@@ -49,3 +50,9 @@ func (r *categoryRepositoryImpl) FindBySlug(slug string) (*models.Category, erro
 	err := r.db.Where("slug = ?", slug).First(&category).Error
 	return &category, err
 }
+
+func (r *categoryRepositoryImpl) FindAllBySlug(slugs []string) ([]models.Category, error) {
+	var categories []models.Category
+	err := r.db.Where("slug IN ?", slugs).Find(&categories).Error
+	return categories, err
+}
@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"time"
+	"yp-blog-api/internal/models"
+)
+
+type CommentRepository interface {
+	Create(comment *models.Comment) error
+	Update(comment *models.Comment) error
+	FindById(id uint) (*models.Comment, error)
+	FindAllByBlogId(blogId uint) ([]models.Comment, error)
+	CountVisibleByBlogIds(blogIds []uint) (map[uint]int64, error)
+	FindAllByStatus(status string) ([]models.Comment, error)
+	CountByContentHashSince(contentHash string, since time.Time) (int64, error)
+	CountByIpAddressSince(ipAddress string, since time.Time) (int64, error)
+	ExistsApprovedByUserIdForAuthor(userId uint, authorId uint) (bool, error)
+	FindModerationRuleByAuthorId(authorId uint) (*models.CommentModerationRule, error)
+	SaveModerationRule(rule *models.CommentModerationRule) error
+}
+
+type commentRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return &commentRepositoryImpl{db: db}
+}
+
+func (r *commentRepositoryImpl) Create(comment *models.Comment) error {
+	return r.db.Create(comment).Error
+}
+
+func (r *commentRepositoryImpl) Update(comment *models.Comment) error {
+	return r.db.Save(comment).Error
+}
+
+func (r *commentRepositoryImpl) FindById(id uint) (*models.Comment, error) {
+	var comment models.Comment
+	err := r.db.Preload("Author").First(&comment, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// FindAllByBlogId retrieves every comment of a blog, deleted ones included, oldest first
+func (r *commentRepositoryImpl) FindAllByBlogId(blogId uint) ([]models.Comment, error) {
+	var comments []models.Comment
+	err := r.db.Preload("Author").
+		Where("blog_id = ?", blogId).
+		Order("created_at ASC, id ASC").
+		Find(&comments).Error
+	return comments, err
+}
+
+// CountVisibleByBlogIds counts the approved, non-deleted comments of each blog
+func (r *commentRepositoryImpl) CountVisibleByBlogIds(blogIds []uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64)
+	if len(blogIds) == 0 {
+		return counts, nil
+	}
+	var rows []struct {
+		BlogID uint
+		Total  int64
+	}
+	err := r.db.Model(&models.Comment{}).
+		Select("blog_id, COUNT(*) AS total").
+		Where("blog_id IN ? AND status = ? AND is_deleted = ?", blogIds, models.CommentStatusApproved, false).
+		Group("blog_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.BlogID] = row.Total
+	}
+	return counts, nil
+}
+
+// FindAllByStatus retrieves the live comments in a moderation status, oldest first
+func (r *commentRepositoryImpl) FindAllByStatus(status string) ([]models.Comment, error) {
+	var comments []models.Comment
+	err := r.db.Preload("Author").
+		Preload("Blog").
+		Preload("Blog.Author").
+		Where("status = ? AND is_deleted = ?", status, false).
+		Order("created_at ASC, id ASC").
+		Find(&comments).Error
+	return comments, err
+}
+
+// CountByContentHashSince counts comments with the same normalised body posted since a given time
+func (r *commentRepositoryImpl) CountByContentHashSince(contentHash string, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Comment{}).
+		Where("content_hash = ? AND created_at >= ?", contentHash, since).
+		Count(&count).Error
+	return count, err
+}
+
+// CountByIpAddressSince counts comments posted from an IP address since a given time
+func (r *commentRepositoryImpl) CountByIpAddressSince(ipAddress string, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Comment{}).
+		Where("ip_address = ? AND created_at >= ?", ipAddress, since).
+		Count(&count).Error
+	return count, err
+}
+
+// ExistsApprovedByUserIdForAuthor reports whether a user has an approved comment on any
+// blog of the given author
+func (r *commentRepositoryImpl) ExistsApprovedByUserIdForAuthor(userId uint, authorId uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Comment{}).
+		Joins("JOIN blogs b ON b.id = comments.blog_id").
+		Where("comments.author_id = ? AND comments.status = ? AND b.author_id = ?",
+			userId, models.CommentStatusApproved, authorId).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FindModerationRuleByAuthorId returns the author's moderation rule, or nil when none is set
+func (r *commentRepositoryImpl) FindModerationRuleByAuthorId(authorId uint) (*models.CommentModerationRule, error) {
+	var rule models.CommentModerationRule
+	err := r.db.Where("author_id = ?", authorId).First(&rule).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *commentRepositoryImpl) SaveModerationRule(rule *models.CommentModerationRule) error {
+	return r.db.Save(rule).Error
+}
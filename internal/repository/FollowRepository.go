@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"yp-blog-api/internal/models"
+)
+
+type FollowRepository interface {
+	CreateIfNotExists(follow *models.Follow) error
+	DeleteByFollowerIdAndFolloweeId(followerId uint, followeeId uint) error
+	ExistsByFollowerIdAndFolloweeId(followerId uint, followeeId uint) (bool, error)
+	CountByFolloweeId(followeeId uint) (int64, error)
+	CountByFollowerId(followerId uint) (int64, error)
+	FindFolloweesByFollowerId(followerId uint) ([]models.User, error)
+	FindFollowerIdsByFolloweeId(followeeId uint) ([]uint, error)
+
+	CreateTagFollowIfNotExists(tagFollow *models.TagFollow) error
+	DeleteTagFollowByUserIdAndTagId(userId uint, tagId uint) error
+	FindFollowedTagsByUserId(userId uint) ([]models.Tag, error)
+}
+
+type followRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewFollowRepository(db *gorm.DB) FollowRepository {
+	return &followRepositoryImpl{db: db}
+}
+
+// CreateIfNotExists inserts a follow, doing nothing when the user already follows the author
+func (r *followRepositoryImpl) CreateIfNotExists(follow *models.Follow) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(follow).Error
+}
+
+func (r *followRepositoryImpl) DeleteByFollowerIdAndFolloweeId(followerId uint, followeeId uint) error {
+	return r.db.Where("follower_id = ? AND followee_id = ?", followerId, followeeId).Delete(&models.Follow{}).Error
+}
+
+func (r *followRepositoryImpl) ExistsByFollowerIdAndFolloweeId(followerId uint, followeeId uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Follow{}).
+		Where("follower_id = ? AND followee_id = ?", followerId, followeeId).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *followRepositoryImpl) CountByFolloweeId(followeeId uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Follow{}).Where("followee_id = ?", followeeId).Count(&count).Error
+	return count, err
+}
+
+func (r *followRepositoryImpl) CountByFollowerId(followerId uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Follow{}).Where("follower_id = ?", followerId).Count(&count).Error
+	return count, err
+}
+
+// FindFolloweesByFollowerId lists the authors a user follows, most recently followed first
+func (r *followRepositoryImpl) FindFolloweesByFollowerId(followerId uint) ([]models.User, error) {
+	var users []models.User
+	err := r.db.Joins("JOIN follows f ON f.followee_id = users.id").
+		Where("f.follower_id = ?", followerId).
+		Order("f.created_at DESC, f.id DESC").
+		Find(&users).Error
+	return users, err
+}
+
+func (r *followRepositoryImpl) FindFollowerIdsByFolloweeId(followeeId uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&models.Follow{}).Where("followee_id = ?", followeeId).Pluck("follower_id", &ids).Error
+	return ids, err
+}
+
+// CreateTagFollowIfNotExists inserts a tag follow, doing nothing when the user already follows the tag
+func (r *followRepositoryImpl) CreateTagFollowIfNotExists(tagFollow *models.TagFollow) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(tagFollow).Error
+}
+
+func (r *followRepositoryImpl) DeleteTagFollowByUserIdAndTagId(userId uint, tagId uint) error {
+	return r.db.Where("user_id = ? AND tag_id = ?", userId, tagId).Delete(&models.TagFollow{}).Error
+}
+
+// FindFollowedTagsByUserId lists the tags a user follows by title
+func (r *followRepositoryImpl) FindFollowedTagsByUserId(userId uint) ([]models.Tag, error) {
+	var tags []models.Tag
+	err := r.db.Joins("JOIN tag_follows tf ON tf.tag_id = tags.id").
+		Where("tf.user_id = ? AND tags.is_deleted = false", userId).
+		Order("tags.title").
+		Find(&tags).Error
+	return tags, err
+}
@@ -0,0 +1,169 @@
+package repositories
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+	"yp-blog-api/internal/models"
+)
+
+type JobRepository interface {
+	Enqueue(job *models.Job) (bool, error)
+	Claim(now time.Time, lockedUntil time.Time) (*models.Job, error)
+	Update(job *models.Job) error
+	Complete(job *models.Job, attempt int) (bool, error)
+	FindById(id uint) (*models.Job, error)
+	FindAll(status string, jobType string, limit int) ([]models.Job, error)
+}
+
+type jobRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewJobRepository(db *gorm.DB) JobRepository {
+	return &jobRepositoryImpl{db: db}
+}
+
+// Enqueue inserts a job and reports whether it was created. A job with a unique key
+// is not created while another job with the same key is queued or running; job is
+// then filled with that other job.
+func (r *jobRepositoryImpl) Enqueue(job *models.Job) (bool, error) {
+	created := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if job.UniqueKey != "" {
+			var existing models.Job
+			err := tx.Where("unique_key = ? AND status IN ?", job.UniqueKey, []string{models.JobStatusQueued, models.JobStatusRunning}).
+				First(&existing).Error
+			if err == nil {
+				*job = existing
+				return nil
+			}
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+		}
+		created = true
+		return tx.Create(job).Error
+	})
+	return created, err
+}
+
+// Claim locks the next job that is due, or whose worker let its lock expire, and
+// counts the attempt. It returns nil when no job is ready. Jobs whose worker let the
+// lock of their last attempt expire, like jobs that crash the process, are dead.
+func (r *jobRepositoryImpl) Claim(now time.Time, lockedUntil time.Time) (*models.Job, error) {
+	var claimed *models.Job
+	err := retryOnBusy(func() error {
+		return r.db.Transaction(func(tx *gorm.DB) error {
+			var err error
+			claimed, err = r.claim(tx, now, lockedUntil)
+			return err
+		})
+	})
+	return claimed, err
+}
+
+// claim marks jobs whose last attempt crashed as dead, then claims the next ready job
+func (r *jobRepositoryImpl) claim(tx *gorm.DB, now time.Time, lockedUntil time.Time) (*models.Job, error) {
+	err := tx.Model(&models.Job{}).
+		Where("status = ? AND locked_until <= ? AND attempts >= max_attempts", models.JobStatusRunning, now).
+		Updates(map[string]interface{}{
+			"status":       models.JobStatusDead,
+			"locked_until": nil,
+			"finished_at":  now,
+			"last_error":   "the worker stopped before the last attempt finished",
+		}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	ready := tx.Where("(status = ? AND run_at <= ?) OR (status = ? AND locked_until <= ? AND attempts < max_attempts)",
+		models.JobStatusQueued, now, models.JobStatusRunning, now)
+	var job models.Job
+	err = ready.Order("run_at ASC, id ASC").First(&job).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Only claim the job if no other worker did in the meantime
+	result := tx.Model(&models.Job{}).
+		Where("id = ? AND status = ? AND attempts = ?", job.ID, job.Status, job.Attempts).
+		Updates(map[string]interface{}{
+			"status":       models.JobStatusRunning,
+			"locked_until": lockedUntil,
+			"attempts":     gorm.Expr("attempts + 1"),
+		})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return nil, result.Error
+	}
+	job.Status = models.JobStatusRunning
+	job.LockedUntil = &lockedUntil
+	job.Attempts++
+	return &job, nil
+}
+
+func (r *jobRepositoryImpl) Update(job *models.Job) error {
+	return r.db.Save(job).Error
+}
+
+// Complete writes the outcome of an attempt of a job, and reports whether it did. The
+// outcome is dropped when the job is no longer running that attempt, because its lock
+// expired and another worker claimed it again.
+func (r *jobRepositoryImpl) Complete(job *models.Job, attempt int) (bool, error) {
+	var completed bool
+	err := retryOnBusy(func() error {
+		result := r.db.Model(&models.Job{}).
+			Where("id = ? AND status = ? AND attempts = ?", job.ID, models.JobStatusRunning, attempt).
+			Select("status", "last_error", "run_at", "locked_until", "finished_at").
+			Updates(job)
+		completed = result.RowsAffected > 0
+		return result.Error
+	})
+	return completed, err
+}
+
+// busyRetries is how many times a job write is tried while SQLite reports the database busy
+const busyRetries = 5
+
+// retryOnBusy runs fn again while SQLite reports the database busy. The busy timeout does
+// not cover a transaction that read before another connection wrote, which has to start over.
+func retryOnBusy(fn func() error) error {
+	var err error
+	for attempt := 1; attempt <= busyRetries; attempt++ {
+		if err = fn(); !isBusy(err) {
+			return err
+		}
+		time.Sleep(time.Duration(attempt) * 20 * time.Millisecond)
+	}
+	return err
+}
+
+func isBusy(err error) bool {
+	return err != nil && (strings.Contains(err.Error(), "SQLITE_BUSY") || strings.Contains(err.Error(), "database is locked"))
+}
+
+func (r *jobRepositoryImpl) FindById(id uint) (*models.Job, error) {
+	var job models.Job
+	if err := r.db.First(&job, id).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// FindAll lists jobs, newest first, optionally in one status and of one type
+func (r *jobRepositoryImpl) FindAll(status string, jobType string, limit int) ([]models.Job, error) {
+	query := r.db.Model(&models.Job{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if jobType != "" {
+		query = query.Where("type = ?", jobType)
+	}
+	var jobs []models.Job
+	err := query.Order("created_at DESC, id DESC").Limit(limit).Find(&jobs).Error
+	return jobs, err
+}
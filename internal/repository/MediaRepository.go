@@ -0,0 +1,168 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"time"
+	"yp-blog-api/internal/models"
+)
+
+type MediaRepository interface {
+	Create(media *models.Media) error
+	FindById(id uint) (*models.Media, error)
+	FindByIdAndOwner(id uint, ownerId uint) (*models.Media, error)
+	FindAllByOwner(ownerId uint, limit int) ([]models.Media, error)
+	FindAllByKeys(keys []string) ([]models.Media, error)
+	FindAllUnprocessedIds() ([]uint, error)
+	FindThumbnailsByBlogIds(blogIds []uint) (map[uint]models.Media, error)
+	Delete(media *models.Media) error
+	SaveVariants(media *models.Media, variants []models.MediaVariant) error
+	ReplaceBlogReferences(blogId uint, references []models.MediaReference) error
+}
+
+type mediaRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewMediaRepository(db *gorm.DB) MediaRepository {
+	return &mediaRepositoryImpl{db: db}
+}
+
+func (r *mediaRepositoryImpl) Create(media *models.Media) error {
+	return r.db.Create(media).Error
+}
+
+// FindById retrieves a media file with its variants
+func (r *mediaRepositoryImpl) FindById(id uint) (*models.Media, error) {
+	var media models.Media
+	if err := r.db.Preload("Variants").First(&media, id).Error; err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
+
+// FindByIdAndOwner retrieves a media file of the given owner with the blogs using it
+func (r *mediaRepositoryImpl) FindByIdAndOwner(id uint, ownerId uint) (*models.Media, error) {
+	var media models.Media
+	err := r.db.Preload("Variants").Preload("References.Blog.Author").
+		Where("id = ? AND owner_id = ?", id, ownerId).
+		First(&media).Error
+	if err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
+
+// FindAllByOwner retrieves the newest media files of an owner with the blogs using them
+func (r *mediaRepositoryImpl) FindAllByOwner(ownerId uint, limit int) ([]models.Media, error) {
+	var media []models.Media
+	err := r.db.Preload("Variants").Preload("References.Blog.Author").
+		Where("owner_id = ?", ownerId).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&media).Error
+	return media, err
+}
+
+// FindAllByKeys retrieves the media files stored under the given keys, or having a
+// variant stored under one of them
+func (r *mediaRepositoryImpl) FindAllByKeys(keys []string) ([]models.Media, error) {
+	var media []models.Media
+	if len(keys) == 0 {
+		return media, nil
+	}
+	err := r.db.Preload("Variants").
+		Where("storage_key IN ? OR id IN (?)", keys,
+			r.db.Model(&models.MediaVariant{}).Select("media_id").Where("storage_key IN ?", keys)).
+		Find(&media).Error
+	return media, err
+}
+
+// FindAllUnprocessedIds retrieves the ids of the media files whose variants are not generated yet
+func (r *mediaRepositoryImpl) FindAllUnprocessedIds() ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&models.Media{}).Where("processed_at IS NULL").Order("id").Pluck("id", &ids).Error
+	return ids, err
+}
+
+// FindThumbnailsByBlogIds retrieves the media file each blog uses as its thumbnail,
+// with its variants, keyed by blog id
+func (r *mediaRepositoryImpl) FindThumbnailsByBlogIds(blogIds []uint) (map[uint]models.Media, error) {
+	thumbnails := make(map[uint]models.Media)
+	if len(blogIds) == 0 {
+		return thumbnails, nil
+	}
+	var references []models.MediaReference
+	err := r.db.Where("blog_id IN ? AND field = ?", blogIds, models.MediaReferenceThumbnail).Find(&references).Error
+	if err != nil || len(references) == 0 {
+		return thumbnails, err
+	}
+	mediaIds := make([]uint, len(references))
+	for i, reference := range references {
+		mediaIds[i] = reference.MediaID
+	}
+	var media []models.Media
+	if err := r.db.Preload("Variants").Where("id IN ?", mediaIds).Find(&media).Error; err != nil {
+		return nil, err
+	}
+	byId := make(map[uint]models.Media, len(media))
+	for _, file := range media {
+		byId[file.ID] = file
+	}
+	for _, reference := range references {
+		if file, ok := byId[reference.MediaID]; ok {
+			thumbnails[reference.BlogID] = file
+		}
+	}
+	return thumbnails, nil
+}
+
+// Delete deletes a media file with its variants
+func (r *mediaRepositoryImpl) Delete(media *models.Media) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("media_id = ?", media.ID).Delete(&models.MediaVariant{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(media).Error
+	})
+}
+
+// SaveVariants replaces the variants of a media file and saves its dimensions and
+// placeholder, marking it processed
+func (r *mediaRepositoryImpl) SaveVariants(media *models.Media, variants []models.MediaVariant) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("media_id = ?", media.ID).Delete(&models.MediaVariant{}).Error; err != nil {
+			return err
+		}
+		if len(variants) > 0 {
+			if err := tx.Create(&variants).Error; err != nil {
+				return err
+			}
+		}
+		now := time.Now()
+		err := tx.Model(&models.Media{}).Where("id = ?", media.ID).Updates(map[string]interface{}{
+			"width":        media.Width,
+			"height":       media.Height,
+			"placeholder":  media.Placeholder,
+			"processed_at": now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		media.ProcessedAt = &now
+		media.Variants = variants
+		return nil
+	})
+}
+
+// ReplaceBlogReferences swaps the media references of a blog for the given ones
+func (r *mediaRepositoryImpl) ReplaceBlogReferences(blogId uint, references []models.MediaReference) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("blog_id = ?", blogId).Delete(&models.MediaReference{}).Error; err != nil {
+			return err
+		}
+		if len(references) == 0 {
+			return nil
+		}
+		return tx.Create(&references).Error
+	})
+}
@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+	"yp-blog-api/internal/models"
+)
+
+type NotificationRepository interface {
+	CreateAll(notifications []models.Notification) error
+	FindAllByUserId(userId uint, unreadOnly bool, limit int) ([]models.Notification, error)
+	CountUnreadByUserId(userId uint) (int64, error)
+	MarkReadByIdAndUserId(id uint, userId uint) (bool, error)
+	MarkAllReadByUserId(userId uint) error
+
+	FindPreferencesByUserId(userId uint) ([]models.NotificationPreference, error)
+	SavePreferences(preferences []models.NotificationPreference) error
+	FindDisabledUserIds(userIds []uint, notificationType string, channel string) (map[uint]bool, error)
+}
+
+type notificationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepositoryImpl{db: db}
+}
+
+func (r *notificationRepositoryImpl) CreateAll(notifications []models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return r.db.CreateInBatches(notifications, 100).Error
+}
+
+// FindAllByUserId lists a user's notifications, newest first
+func (r *notificationRepositoryImpl) FindAllByUserId(userId uint, unreadOnly bool, limit int) ([]models.Notification, error) {
+	query := r.db.Where("user_id = ?", userId)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	var notifications []models.Notification
+	err := query.Order("created_at DESC, id DESC").Limit(limit).Find(&notifications).Error
+	return notifications, err
+}
+
+func (r *notificationRepositoryImpl) CountUnreadByUserId(userId uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userId).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkReadByIdAndUserId marks one of the user's notifications as read and reports
+// whether the notification exists
+func (r *notificationRepositoryImpl) MarkReadByIdAndUserId(id uint, userId uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Notification{}).Where("id = ? AND user_id = ?", id, userId).Count(&count).Error
+	if err != nil || count == 0 {
+		return false, err
+	}
+	err = r.db.Model(&models.Notification{}).
+		Where("id = ? AND user_id = ? AND read_at IS NULL", id, userId).
+		Update("read_at", time.Now()).Error
+	return true, err
+}
+
+func (r *notificationRepositoryImpl) MarkAllReadByUserId(userId uint) error {
+	return r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userId).
+		Update("read_at", time.Now()).Error
+}
+
+func (r *notificationRepositoryImpl) FindPreferencesByUserId(userId uint) ([]models.NotificationPreference, error) {
+	var preferences []models.NotificationPreference
+	err := r.db.Where("user_id = ?", userId).Find(&preferences).Error
+	return preferences, err
+}
+
+// SavePreferences inserts or updates preferences by user, type and channel
+func (r *notificationRepositoryImpl) SavePreferences(preferences []models.NotificationPreference) error {
+	if len(preferences) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}, {Name: "channel"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled"}),
+	}).Create(&preferences).Error
+}
+
+// FindDisabledUserIds reports which of the given users switched a notification type off for a channel
+func (r *notificationRepositoryImpl) FindDisabledUserIds(userIds []uint, notificationType string, channel string) (map[uint]bool, error) {
+	disabled := make(map[uint]bool)
+	if len(userIds) == 0 {
+		return disabled, nil
+	}
+	var ids []uint
+	err := r.db.Model(&models.NotificationPreference{}).
+		Where("user_id IN ? AND type = ? AND channel = ? AND enabled = ?", userIds, notificationType, channel, false).
+		Pluck("user_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		disabled[id] = true
+	}
+	return disabled, nil
+}
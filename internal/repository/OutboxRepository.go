@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"time"
+	"yp-blog-api/internal/models"
+)
+
+type OutboxRepository interface {
+	FindPending(now time.Time, limit int) ([]models.OutboxEvent, error)
+	Update(event *models.OutboxEvent) error
+	DeleteProcessedBefore(before time.Time) error
+}
+
+type outboxRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &outboxRepositoryImpl{db: db}
+}
+
+// FindPending retrieves the unprocessed events whose next attempt is due, oldest first;
+// dead events are left out
+func (r *outboxRepositoryImpl) FindPending(now time.Time, limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	err := r.db.Where("processed_at IS NULL AND dead_at IS NULL AND next_attempt_at <= ?", now).
+		Order("id ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+func (r *outboxRepositoryImpl) Update(event *models.OutboxEvent) error {
+	return r.db.Save(event).Error
+}
+
+func (r *outboxRepositoryImpl) DeleteProcessedBefore(before time.Time) error {
+	return r.db.Where("processed_at < ?", before).Delete(&models.OutboxEvent{}).Error
+}
+
+// createOutboxEvents records events about a blog inside the transaction that saved it
+func createOutboxEvents(tx *gorm.DB, blogId uint, events []models.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	now := time.Now()
+	for i := range events {
+		events[i].AggregateType = models.OutboxAggregateBlog
+		events[i].AggregateID = blogId
+		events[i].NextAttemptAt = now
+	}
+	return tx.Create(&events).Error
+}
@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"yp-blog-api/internal/models"
+)
+
+type ReactionRepository interface {
+	CreateIfNotExists(reaction *models.Reaction) error
+	DeleteByBlogIdAndReactorKeyAndType(blogId uint, reactorKey string, reactionType string) error
+	CountByBlogIds(blogIds []uint) (map[uint]map[string]int64, error)
+	FindTypesByBlogIdAndReactorKey(blogId uint, reactorKey string) ([]string, error)
+}
+
+type reactionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewReactionRepository(db *gorm.DB) ReactionRepository {
+	return &reactionRepositoryImpl{db: db}
+}
+
+// CreateIfNotExists inserts a reaction, doing nothing when the reader already reacted with that type
+func (r *reactionRepositoryImpl) CreateIfNotExists(reaction *models.Reaction) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(reaction).Error
+}
+
+func (r *reactionRepositoryImpl) DeleteByBlogIdAndReactorKeyAndType(blogId uint, reactorKey string, reactionType string) error {
+	return r.db.Where("blog_id = ? AND reactor_key = ? AND type = ?", blogId, reactorKey, reactionType).
+		Delete(&models.Reaction{}).Error
+}
+
+// CountByBlogIds counts the reactions of each blog grouped by reaction type
+func (r *reactionRepositoryImpl) CountByBlogIds(blogIds []uint) (map[uint]map[string]int64, error) {
+	counts := make(map[uint]map[string]int64)
+	if len(blogIds) == 0 {
+		return counts, nil
+	}
+	var rows []struct {
+		BlogID uint
+		Type   string
+		Total  int64
+	}
+	err := r.db.Model(&models.Reaction{}).
+		Select("blog_id, type, COUNT(*) AS total").
+		Where("blog_id IN ?", blogIds).
+		Group("blog_id, type").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if counts[row.BlogID] == nil {
+			counts[row.BlogID] = make(map[string]int64)
+		}
+		counts[row.BlogID][row.Type] = row.Total
+	}
+	return counts, nil
+}
+
+// FindTypesByBlogIdAndReactorKey lists the reaction types a reader left on a blog
+func (r *reactionRepositoryImpl) FindTypesByBlogIdAndReactorKey(blogId uint, reactorKey string) ([]string, error) {
+	var types []string
+	err := r.db.Model(&models.Reaction{}).
+		Where("blog_id = ? AND reactor_key = ?", blogId, reactorKey).
+		Order("type").
+		Pluck("type", &types).Error
+	return types, err
+}
@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"yp-blog-api/internal/models"
+)
+
+type ReadingListRepository interface {
+	Save(readingList *models.ReadingList) error
+	DeleteById(id uint) error
+	FindByIdAndUserId(id uint, userId uint) (models.ReadingList, error)
+	FindAllByUserId(userId uint) ([]models.ReadingList, error)
+	CountItemsByReadingListIds(readingListIds []uint) (map[uint]int64, error)
+	ExistsByUserIdAndName(userId uint, name string, excludeId uint) (bool, error)
+
+	AddItemIfNotExists(item *models.ReadingListItem) error
+	RemoveItem(readingListId uint, blogId uint) error
+	FindBlogsByReadingListId(readingListId uint) ([]models.Blog, error)
+	Reorder(readingListId uint, blogIds []uint) error
+}
+
+type readingListRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewReadingListRepository(db *gorm.DB) ReadingListRepository {
+	return &readingListRepositoryImpl{db: db}
+}
+
+func (r *readingListRepositoryImpl) Save(readingList *models.ReadingList) error {
+	return r.db.Save(readingList).Error
+}
+
+// DeleteById deletes a reading list together with its items
+func (r *readingListRepositoryImpl) DeleteById(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("reading_list_id = ?", id).Delete(&models.ReadingListItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.ReadingList{}, id).Error
+	})
+}
+
+func (r *readingListRepositoryImpl) FindByIdAndUserId(id uint, userId uint) (models.ReadingList, error) {
+	var readingList models.ReadingList
+	err := r.db.Where("id = ? AND user_id = ?", id, userId).First(&readingList).Error
+	return readingList, err
+}
+
+func (r *readingListRepositoryImpl) FindAllByUserId(userId uint) ([]models.ReadingList, error) {
+	var readingLists []models.ReadingList
+	err := r.db.Where("user_id = ?", userId).Order("name").Find(&readingLists).Error
+	return readingLists, err
+}
+
+// CountItemsByReadingListIds counts the blogs in each reading list
+func (r *readingListRepositoryImpl) CountItemsByReadingListIds(readingListIds []uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64)
+	if len(readingListIds) == 0 {
+		return counts, nil
+	}
+	var rows []struct {
+		ReadingListID uint
+		Total         int64
+	}
+	err := r.db.Model(&models.ReadingListItem{}).
+		Select("reading_list_id, COUNT(*) AS total").
+		Where("reading_list_id IN ?", readingListIds).
+		Group("reading_list_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.ReadingListID] = row.Total
+	}
+	return counts, nil
+}
+
+// ExistsByUserIdAndName checks whether the user has another reading list with the same name
+func (r *readingListRepositoryImpl) ExistsByUserIdAndName(userId uint, name string, excludeId uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.ReadingList{}).
+		Where("user_id = ? AND LOWER(name) = LOWER(?) AND id <> ?", userId, name, excludeId).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// AddItemIfNotExists appends a blog to the end of a reading list, doing nothing when
+// the blog is already in it
+func (r *readingListRepositoryImpl) AddItemIfNotExists(item *models.ReadingListItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var last int
+		err := tx.Model(&models.ReadingListItem{}).
+			Select("COALESCE(MAX(position), 0)").
+			Where("reading_list_id = ?", item.ReadingListID).
+			Scan(&last).Error
+		if err != nil {
+			return err
+		}
+		item.Position = last + 1
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(item).Error; err != nil {
+			return err
+		}
+		// Adding a blog counts as a change to the list
+		return tx.Model(&models.ReadingList{}).Where("id = ?", item.ReadingListID).Update("updated_at", gorm.Expr("CURRENT_TIMESTAMP")).Error
+	})
+}
+
+func (r *readingListRepositoryImpl) RemoveItem(readingListId uint, blogId uint) error {
+	return r.db.Where("reading_list_id = ? AND blog_id = ?", readingListId, blogId).Delete(&models.ReadingListItem{}).Error
+}
+
+// FindBlogsByReadingListId lists the visible blogs of a reading list in list order
+func (r *readingListRepositoryImpl) FindBlogsByReadingListId(readingListId uint) ([]models.Blog, error) {
+	var blogs []models.Blog
+	err := r.db.Preload("Author").
+		Joins("JOIN reading_list_items rli ON rli.blog_id = blogs.id").
+		Where("rli.reading_list_id = ? AND blogs.published = ? AND blogs.is_deleted = false", readingListId, true).
+		Order("rli.position, rli.id").
+		Find(&blogs).Error
+	return blogs, err
+}
+
+// Reorder moves the given blogs to the front of the reading list in the given order
+func (r *readingListRepositoryImpl) Reorder(readingListId uint, blogIds []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return reorderPositions(tx.Model(&models.ReadingListItem{}).Where("reading_list_id = ?", readingListId), blogIds)
+	})
+}
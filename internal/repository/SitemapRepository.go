@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+	"yp-blog-api/internal/models"
+)
+
+type SitemapRepository interface {
+	Save(entry *models.SitemapEntry) error
+	DeleteByRef(kind string, refId uint) error
+	ReplaceAll(entries []models.SitemapEntry) error
+	Count() (int64, error)
+	FindPage(offset int, limit int) ([]models.SitemapEntry, error)
+	FindPageLastMod(offset int, limit int) (time.Time, error)
+}
+
+type sitemapRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewSitemapRepository(db *gorm.DB) SitemapRepository {
+	return &sitemapRepositoryImpl{db: db}
+}
+
+// Save inserts the entry, or updates the path and lastmod of the entry for the same page
+func (r *sitemapRepositoryImpl) Save(entry *models.SitemapEntry) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "kind"}, {Name: "ref_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"path", "last_mod"}),
+	}).Create(entry).Error
+}
+
+func (r *sitemapRepositoryImpl) DeleteByRef(kind string, refId uint) error {
+	return r.db.Where("kind = ? AND ref_id = ?", kind, refId).Delete(&models.SitemapEntry{}).Error
+}
+
+// ReplaceAll swaps every entry for the given ones in a single transaction
+func (r *sitemapRepositoryImpl) ReplaceAll(entries []models.SitemapEntry) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&models.SitemapEntry{}).Error; err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(entries, 500).Error
+	})
+}
+
+func (r *sitemapRepositoryImpl) Count() (int64, error) {
+	var count int64
+	err := r.db.Model(&models.SitemapEntry{}).Count(&count).Error
+	return count, err
+}
+
+// FindPage retrieves entries in a stable order, so pages keep their content as entries are added
+func (r *sitemapRepositoryImpl) FindPage(offset int, limit int) ([]models.SitemapEntry, error) {
+	var entries []models.SitemapEntry
+	err := r.db.Order("id").Offset(offset).Limit(limit).Find(&entries).Error
+	return entries, err
+}
+
+// FindPageLastMod retrieves the most recent lastmod of the entries on a page
+func (r *sitemapRepositoryImpl) FindPageLastMod(offset int, limit int) (time.Time, error) {
+	var entries []models.SitemapEntry
+	err := r.db.Select("last_mod").Order("id").Offset(offset).Limit(limit).Find(&entries).Error
+	var lastMod time.Time
+	for _, entry := range entries {
+		if entry.LastMod.After(lastMod) {
+			lastMod = entry.LastMod
+		}
+	}
+	return lastMod, err
+}
@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"yp-blog-api/internal/models"
+)
+
+type UserRepository interface {
+	FindById(id uint) (*models.User, error)
+	FindByUserName(userName string) (*models.User, error)
+	FindByEmail(email string) (*models.User, error)
+}
+
+type userRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) UserRepository {
+	return &userRepositoryImpl{db: db}
+}
+
+func (r *userRepositoryImpl) FindById(id uint) (*models.User, error) {
+	var user models.User
+	err := r.db.First(&user, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepositoryImpl) FindByUserName(userName string) (*models.User, error) {
+	var user models.User
+	err := r.db.Where("user_name = ?", userName).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepositoryImpl) FindByEmail(email string) (*models.User, error) {
+	var user models.User
+	err := r.db.Where("LOWER(email) = LOWER(?)", email).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
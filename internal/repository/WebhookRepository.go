@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"time"
+	"yp-blog-api/internal/models"
+)
+
+type WebhookRepository interface {
+	Create(webhook *models.Webhook) error
+	Update(webhook *models.Webhook) error
+	FindById(id uint) (*models.Webhook, error)
+	FindAll() ([]models.Webhook, error)
+	FindAllActive() ([]models.Webhook, error)
+	DeleteById(id uint) error
+
+	CreateDeliveries(deliveries []models.WebhookDelivery) error
+	UpdateDelivery(delivery *models.WebhookDelivery) error
+	FindDeliveryByIdAndWebhookId(id uint, webhookId uint) (*models.WebhookDelivery, error)
+	FindDeliveriesByWebhookId(webhookId uint, status string, limit int) ([]models.WebhookDelivery, error)
+	FindDueDeliveries(now time.Time, limit int) ([]models.WebhookDelivery, error)
+	CreateAttempt(attempt *models.WebhookDeliveryAttempt) error
+}
+
+type webhookRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepositoryImpl{db: db}
+}
+
+func (r *webhookRepositoryImpl) Create(webhook *models.Webhook) error {
+	return r.db.Create(webhook).Error
+}
+
+func (r *webhookRepositoryImpl) Update(webhook *models.Webhook) error {
+	return r.db.Save(webhook).Error
+}
+
+func (r *webhookRepositoryImpl) FindById(id uint) (*models.Webhook, error) {
+	var webhook models.Webhook
+	if err := r.db.First(&webhook, id).Error; err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *webhookRepositoryImpl) FindAll() ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := r.db.Order("id ASC").Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *webhookRepositoryImpl) FindAllActive() ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := r.db.Where("active = ?", true).Find(&webhooks).Error
+	return webhooks, err
+}
+
+// DeleteById removes a webhook together with its delivery log
+func (r *webhookRepositoryImpl) DeleteById(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		deliveryIds := tx.Model(&models.WebhookDelivery{}).Select("id").Where("webhook_id = ?", id)
+		if err := tx.Where("delivery_id IN (?)", deliveryIds).Delete(&models.WebhookDeliveryAttempt{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("webhook_id = ?", id).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Webhook{}, id).Error
+	})
+}
+
+func (r *webhookRepositoryImpl) CreateDeliveries(deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.CreateInBatches(deliveries, 100).Error
+}
+
+func (r *webhookRepositoryImpl) UpdateDelivery(delivery *models.WebhookDelivery) error {
+	return r.db.Omit("AttemptLog").Save(delivery).Error
+}
+
+// FindDeliveryByIdAndWebhookId retrieves a delivery of a webhook with its attempts, oldest first
+func (r *webhookRepositoryImpl) FindDeliveryByIdAndWebhookId(id uint, webhookId uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := r.db.Preload("AttemptLog", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC, id ASC")
+	}).Where("id = ? AND webhook_id = ?", id, webhookId).First(&delivery).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// FindDeliveriesByWebhookId lists the deliveries of a webhook, newest first, optionally in one status
+func (r *webhookRepositoryImpl) FindDeliveriesByWebhookId(webhookId uint, status string, limit int) ([]models.WebhookDelivery, error) {
+	query := r.db.Where("webhook_id = ?", webhookId)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var deliveries []models.WebhookDelivery
+	err := query.Order("created_at DESC, id DESC").Limit(limit).Find(&deliveries).Error
+	return deliveries, err
+}
+
+// FindDueDeliveries retrieves the pending deliveries whose next attempt is due, oldest first
+func (r *webhookRepositoryImpl) FindDueDeliveries(now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := r.db.Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryStatusPending, now).
+		Order("next_attempt_at ASC, id ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+func (r *webhookRepositoryImpl) CreateAttempt(attempt *models.WebhookDeliveryAttempt) error {
+	return r.db.Create(attempt).Error
+}
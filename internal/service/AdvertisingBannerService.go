@@ -3,63 +3,496 @@ package service
 import (
 	"errors"
 	"fmt"
+	"log"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+	"yp-blog-api/internal/dto"
+	"yp-blog-api/internal/mapping"
 	"yp-blog-api/internal/models"
 	"yp-blog-api/internal/repository"
 )
 
+// Banner statuses shown to administrators
+const (
+	BannerStatusScheduled = "scheduled"
+	BannerStatusActive    = "active"
+	BannerStatusCapped    = "capped"
+	BannerStatusEnded     = "ended"
+	BannerStatusDeleted   = "deleted"
+)
+
+const (
+	// defaultBannerReportDays is how many days a report covers when no range is given
+	defaultBannerReportDays = 30
+	// maxBannerReportDays caps the range of a report
+	maxBannerReportDays = 366
+	// bannerReportDayLayout is the layout of report days
+	bannerReportDayLayout = "2006-01-02"
+)
+
+// bannerLocalTimeLayouts are the layouts accepted for campaign times without a UTC offset,
+// which are read in the banner's time zone
+var bannerLocalTimeLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02"}
+
+var (
+	ErrBannerNotFound        = errors.New("banner not found")
+	ErrInvalidBannerImageURL = errors.New("banner image URL must use http or https, or be a path on this site")
+	ErrInvalidBannerLink     = errors.New("banner link must use http or https")
+	ErrInvalidBannerTimezone = errors.New("banner timezone must be an IANA time zone such as Europe/Paris")
+	ErrInvalidBannerSchedule = errors.New("banner startsAt and endsAt must be RFC 3339 or local times, with endsAt after startsAt")
+	ErrInvalidReportRange    = errors.New("report from and to must be dates such as 2024-05-31, from before to, at most 366 days apart")
+	ErrUnknownBannerCategory = errors.New("banner categories must be slugs of existing categories")
+)
+
 // AdvertisingBannerService handles the business logic for advertising banners
-type AdvertisingBannerService struct {
-	repo *repositories.AdvertisingBannerRepository
+type AdvertisingBannerService interface {
+	// GetAllBanners retrieves the banners shown to readers now
+	GetAllBanners() ([]dto.AdvertisingBannerDto, error)
+	// GetBannerById retrieves a banner shown to readers now
+	GetBannerById(id int64) (*dto.AdvertisingBannerDto, error)
+
+	// GetAllBannersForAdmin retrieves the banners, deleted ones too when includeDeleted is set
+	GetAllBannersForAdmin(includeDeleted bool) ([]dto.AdvertisingBannerAdminDto, error)
+	GetBannerByIdForAdmin(id int64) (*dto.AdvertisingBannerAdminDto, error)
+	CreateBanner(bannerDto dto.AdvertisingBannerRequestDto) (*dto.AdvertisingBannerAdminDto, error)
+	UpdateBanner(id int64, bannerDto dto.AdvertisingBannerRequestDto) (*dto.AdvertisingBannerAdminDto, error)
+	// DeleteBanner hides a banner from readers; it can be restored
+	DeleteBanner(id int64) error
+	RestoreBanner(id int64) (*dto.AdvertisingBannerAdminDto, error)
+
+	// RecordClick returns the link a banner leads to, counting the click while the banner is shown
+	RecordClick(id int64) (string, error)
+	// GetBannerReports totals the impressions and clicks of every banner from one day to
+	// another; empty days default to the last 30 days in each banner's time zone
+	GetBannerReports(from string, to string) ([]dto.BannerReportDto, error)
+	// GetBannerReport reports the impressions and clicks of a banner day by day, days
+	// being in the banner's time zone
+	GetBannerReport(id int64, from string, to string) (*dto.BannerReportDto, error)
+}
+
+type advertisingBannerServiceImpl struct {
+	repo         *repositories.AdvertisingBannerRepository
+	categoryRepo repositories.CategoryRepository
+	bannerMapper mapper.AdvertisingBannerMapper
 }
 
 // NewAdvertisingBannerService creates a new instance of AdvertisingBannerService
-func NewAdvertisingBannerService(repo *repositories.AdvertisingBannerRepository) *AdvertisingBannerService {
-	return &AdvertisingBannerService{repo: repo}
+func NewAdvertisingBannerService(repo *repositories.AdvertisingBannerRepository, categoryRepo repositories.CategoryRepository, bannerMapper mapper.AdvertisingBannerMapper) AdvertisingBannerService {
+	return &advertisingBannerServiceImpl{
+		repo:         repo,
+		categoryRepo: categoryRepo,
+		bannerMapper: bannerMapper,
+	}
+}
+
+func (s *advertisingBannerServiceImpl) GetAllBanners() ([]dto.AdvertisingBannerDto, error) {
+	banners, err := s.repo.FindAllActive(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return s.bannerMapper.AdvertisingBannerListToDtoList(banners), nil
+}
+
+func (s *advertisingBannerServiceImpl) GetBannerById(id int64) (*dto.AdvertisingBannerDto, error) {
+	banner, err := s.findBanner(id)
+	if err != nil {
+		return nil, err
+	}
+	status, _, err := s.bannerStatus(*banner, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if status != BannerStatusActive {
+		return nil, ErrBannerNotFound
+	}
+	bannerDto := s.bannerMapper.AdvertisingBannerToDto(*banner)
+	return &bannerDto, nil
+}
+
+func (s *advertisingBannerServiceImpl) GetAllBannersForAdmin(includeDeleted bool) ([]dto.AdvertisingBannerAdminDto, error) {
+	var banners []models.AdvertisingBanner
+	var err error
+	if includeDeleted {
+		banners, err = s.repo.FindAll()
+	} else {
+		banners, err = s.repo.FindAllByIsDeletedIsFalse()
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	impressions, err := s.repo.FindImpressionsOnDay(banners, now)
+	if err != nil {
+		return nil, err
+	}
+	bannerDtos := make([]dto.AdvertisingBannerAdminDto, len(banners))
+	for i, banner := range banners {
+		bannerDtos[i] = s.bannerMapper.AdvertisingBannerToAdminDto(banner)
+		bannerDtos[i].ImpressionsToday = impressions[banner.ID]
+		bannerDtos[i].Status = statusOfBanner(banner, impressions[banner.ID], now)
+	}
+	return bannerDtos, nil
+}
+
+func (s *advertisingBannerServiceImpl) GetBannerByIdForAdmin(id int64) (*dto.AdvertisingBannerAdminDto, error) {
+	banner, err := s.findBanner(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toAdminDto(*banner)
+}
+
+func (s *advertisingBannerServiceImpl) CreateBanner(bannerDto dto.AdvertisingBannerRequestDto) (*dto.AdvertisingBannerAdminDto, error) {
+	if err := validateBannerRequest(bannerDto); err != nil {
+		return nil, err
+	}
+	banner := models.AdvertisingBanner{}
+	if err := applyBannerRequest(&banner, bannerDto); err != nil {
+		return nil, err
+	}
+	categories, err := s.findBannerCategories(bannerDto.Categories)
+	if err != nil {
+		return nil, err
+	}
+	banner.Categories = categories
+	if err := s.repo.Save(&banner); err != nil {
+		return nil, fmt.Errorf("error saving banner: %v", err)
+	}
+	return s.toAdminDto(banner)
+}
+
+func (s *advertisingBannerServiceImpl) UpdateBanner(id int64, bannerDto dto.AdvertisingBannerRequestDto) (*dto.AdvertisingBannerAdminDto, error) {
+	if err := validateBannerRequest(bannerDto); err != nil {
+		return nil, err
+	}
+	banner, err := s.findBanner(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyBannerRequest(banner, bannerDto); err != nil {
+		return nil, err
+	}
+	categories, err := s.findBannerCategories(bannerDto.Categories)
+	if err != nil {
+		return nil, err
+	}
+	banner.Categories = categories
+	if err := s.repo.Save(banner); err != nil {
+		return nil, fmt.Errorf("error saving banner: %v", err)
+	}
+	return s.toAdminDto(*banner)
+}
+
+func (s *advertisingBannerServiceImpl) DeleteBanner(id int64) error {
+	_, err := s.setBannerDeleted(id, true)
+	return err
+}
+
+func (s *advertisingBannerServiceImpl) RestoreBanner(id int64) (*dto.AdvertisingBannerAdminDto, error) {
+	return s.setBannerDeleted(id, false)
+}
+
+func (s *advertisingBannerServiceImpl) setBannerDeleted(id int64, deleted bool) (*dto.AdvertisingBannerAdminDto, error) {
+	banner, err := s.findBanner(id)
+	if err != nil {
+		return nil, err
+	}
+	if banner.IsDeleted != deleted {
+		banner.IsDeleted = deleted
+		if err := s.repo.Save(banner); err != nil {
+			return nil, fmt.Errorf("error saving banner: %v", err)
+		}
+	}
+	return s.toAdminDto(*banner)
+}
+
+func (s *advertisingBannerServiceImpl) RecordClick(id int64) (string, error) {
+	banner, err := s.findBanner(id)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	status, _, err := s.bannerStatus(*banner, now)
+	if err != nil {
+		return "", err
+	}
+	switch status {
+	case BannerStatusActive:
+		// Readers are sent on even when the click cannot be counted
+		if err := s.repo.IncrementClicks(*banner, now); err != nil {
+			log.Printf("Error occurred while counting a click on banner %d: %v", banner.ID, err)
+		}
+	case BannerStatusDeleted, BannerStatusScheduled:
+		// Banners that are gone or were never shown have no link to follow
+		return "", ErrBannerNotFound
+	}
+	// Pages rendered before a banner ended or reached its cap still lead on, but only
+	// clicks while it is shown count towards its reports
+	return banner.Link, nil
+}
+
+func (s *advertisingBannerServiceImpl) GetBannerReports(from string, to string) ([]dto.BannerReportDto, error) {
+	// Fail on a bad range even without banners
+	if _, _, err := parseReportRange(from, to, time.UTC); err != nil {
+		return nil, err
+	}
+	banners, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	// Default ranges end today in each banner's time zone, so banners may differ in range
+	totalsByRange := make(map[string]map[int64]models.BannerDailyStat)
+	reports := make([]dto.BannerReportDto, 0, len(banners))
+	for _, banner := range banners {
+		fromDay, toDay, _ := parseReportRange(from, to, banner.Location())
+		totals, ok := totalsByRange[fromDay+"/"+toDay]
+		if !ok {
+			stats, err := s.repo.SumStatsByBanner(fromDay, toDay)
+			if err != nil {
+				return nil, err
+			}
+			totals = make(map[int64]models.BannerDailyStat, len(stats))
+			for _, stat := range stats {
+				totals[stat.BannerID] = stat
+			}
+			totalsByRange[fromDay+"/"+toDay] = totals
+		}
+		total, ok := totals[banner.ID]
+		// Deleted banners only matter for the days they ran
+		if banner.IsDeleted && !ok {
+			continue
+		}
+		reports = append(reports, dto.BannerReportDto{
+			BannerID:    banner.ID,
+			Title:       banner.Title,
+			From:        fromDay,
+			To:          toDay,
+			Impressions: total.Impressions,
+			Clicks:      total.Clicks,
+			CTR:         clickThroughRate(total.Clicks, total.Impressions),
+		})
+	}
+	return reports, nil
+}
+
+func (s *advertisingBannerServiceImpl) GetBannerReport(id int64, from string, to string) (*dto.BannerReportDto, error) {
+	banner, err := s.findBanner(id)
+	if err != nil {
+		return nil, err
+	}
+	fromDay, toDay, err := parseReportRange(from, to, banner.Location())
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.repo.FindDailyStats(banner.ID, fromDay, toDay)
+	if err != nil {
+		return nil, err
+	}
+	byDay := make(map[string]models.BannerDailyStat, len(stats))
+	for _, stat := range stats {
+		byDay[stat.Day] = stat
+	}
+
+	report := &dto.BannerReportDto{BannerID: banner.ID, Title: banner.Title, From: fromDay, To: toDay}
+	// Every day of the range is listed, so charts need not fill the gaps
+	start, _ := time.Parse(bannerReportDayLayout, fromDay)
+	end, _ := time.Parse(bannerReportDayLayout, toDay)
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		stat := byDay[day.Format(bannerReportDayLayout)]
+		report.Days = append(report.Days, dto.BannerDayReportDto{
+			Day:         day.Format(bannerReportDayLayout),
+			Impressions: stat.Impressions,
+			Clicks:      stat.Clicks,
+			CTR:         clickThroughRate(stat.Clicks, stat.Impressions),
+		})
+		report.Impressions += stat.Impressions
+		report.Clicks += stat.Clicks
+	}
+	report.CTR = clickThroughRate(report.Clicks, report.Impressions)
+	return report, nil
+}
+
+// toAdminDto maps a banner for administrators with its impressions and status today
+func (s *advertisingBannerServiceImpl) toAdminDto(banner models.AdvertisingBanner) (*dto.AdvertisingBannerAdminDto, error) {
+	status, impressions, err := s.bannerStatus(banner, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	bannerDto := s.bannerMapper.AdvertisingBannerToAdminDto(banner)
+	bannerDto.ImpressionsToday = impressions
+	bannerDto.Status = status
+	return &bannerDto, nil
 }
 
-// GetAllBanners retrieves all non-deleted advertising banners
-func (s *AdvertisingBannerService) GetAllBanners() ([]models.AdvertisingBanner, error) {
-	return s.repo.FindAllByIsDeletedIsFalse()
+// bannerStatus tells whether a banner is shown at the given time, with its impressions that day
+func (s *advertisingBannerServiceImpl) bannerStatus(banner models.AdvertisingBanner, now time.Time) (string, int64, error) {
+	impressions, err := s.repo.FindImpressionsOnDay([]models.AdvertisingBanner{banner}, now)
+	if err != nil {
+		return "", 0, err
+	}
+	return statusOfBanner(banner, impressions[banner.ID], now), impressions[banner.ID], nil
+}
+
+// statusOfBanner tells whether a banner with the given impressions that day is shown at
+// the given time, following the same rules as the active banners query
+func statusOfBanner(banner models.AdvertisingBanner, impressionsToday int64, now time.Time) string {
+	switch {
+	case banner.IsDeleted:
+		return BannerStatusDeleted
+	case banner.StartsAt != nil && now.Before(*banner.StartsAt):
+		return BannerStatusScheduled
+	case banner.EndsAt != nil && !now.Before(*banner.EndsAt):
+		return BannerStatusEnded
+	case banner.DailyImpressionCap > 0 && impressionsToday >= banner.DailyImpressionCap:
+		return BannerStatusCapped
+	default:
+		return BannerStatusActive
+	}
 }
 
-// GetBannerById retrieves an advertising banner by ID
-func (s *AdvertisingBannerService) GetBannerById(id int64) (*models.AdvertisingBanner, error) {
+// findBanner retrieves a banner, deleted or not
+func (s *advertisingBannerServiceImpl) findBanner(id int64) (*models.AdvertisingBanner, error) {
 	banner, err := s.repo.FindById(id)
 	if err != nil {
 		return nil, err
 	}
 	if banner == nil {
-		return nil, errors.New(fmt.Sprintf("Banner not found with ID: %d", id))
+		return nil, ErrBannerNotFound
 	}
 	return banner, nil
 }
 
-// CreateBanner creates a new advertising banner
-func (s *AdvertisingBannerService) CreateBanner(banner *models.AdvertisingBanner) (*models.AdvertisingBanner, error) {
-	err := s.repo.Save(banner)
-	return banner, err
-}
-
-// UpdateBanner updates an existing advertising banner
-func (s *AdvertisingBannerService) UpdateBanner(id int64, bannerDetails *models.AdvertisingBanner) (*models.AdvertisingBanner, error) {
-	banner, err := s.GetBannerById(id)
+// findBannerCategories looks up the categories a banner is targeted at by their slugs
+func (s *advertisingBannerServiceImpl) findBannerCategories(slugs []string) ([]models.Category, error) {
+	if len(slugs) == 0 {
+		return nil, nil
+	}
+	categories, err := s.categoryRepo.FindAllBySlug(slugs)
 	if err != nil {
 		return nil, err
 	}
+	found := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		found[category.Slug] = true
+	}
+	for _, slug := range slugs {
+		if !found[slug] {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownBannerCategory, slug)
+		}
+	}
+	return categories, nil
+}
 
-	banner.Title = bannerDetails.Title
-	banner.ImageURL = bannerDetails.ImageURL
-	banner.Link = bannerDetails.Link
+func validateBannerRequest(bannerDto dto.AdvertisingBannerRequestDto) error {
+	if err := bannerDto.Validate(); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+	if !isHTTPURL(bannerDto.Link) {
+		return ErrInvalidBannerLink
+	}
+	// Paths on this site, like uploaded images, are accepted; protocol-relative URLs are not
+	isSitePath := strings.HasPrefix(bannerDto.ImageURL, "/") && !strings.HasPrefix(bannerDto.ImageURL, "//")
+	if !isSitePath && !isHTTPURL(bannerDto.ImageURL) {
+		return ErrInvalidBannerImageURL
+	}
+	return nil
+}
 
-	err = s.repo.Save(banner)
-	return banner, err
+// isHTTPURL reports whether value is an absolute http or https URL with a host
+func isHTTPURL(value string) bool {
+	parsed, err := url.Parse(value)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
 }
 
-// DeleteBanner deletes an advertising banner by ID
-func (s *AdvertisingBannerService) DeleteBanner(id int64) error {
-	banner, err := s.GetBannerById(id)
+// applyBannerRequest copies a request onto a banner, reading its campaign times in its time zone
+func applyBannerRequest(banner *models.AdvertisingBanner, bannerDto dto.AdvertisingBannerRequestDto) error {
+	timezone := bannerDto.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	// Local is the server's zone, which would move with the deployment
+	location, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "Local" {
+		return ErrInvalidBannerTimezone
+	}
+	startsAt, err := parseBannerTime(bannerDto.StartsAt, location)
+	if err != nil {
+		return err
+	}
+	endsAt, err := parseBannerTime(bannerDto.EndsAt, location)
 	if err != nil {
 		return err
 	}
-	return s.repo.Delete(banner)
+	if startsAt != nil && endsAt != nil && !endsAt.After(*startsAt) {
+		return ErrInvalidBannerSchedule
+	}
+
+	banner.Title = strings.TrimSpace(bannerDto.Title)
+	banner.ImageURL = strings.TrimSpace(bannerDto.ImageURL)
+	banner.Link = strings.TrimSpace(bannerDto.Link)
+	banner.Timezone = location.String()
+	banner.StartsAt = startsAt
+	banner.EndsAt = endsAt
+	banner.DailyImpressionCap = bannerDto.DailyImpressionCap
+	banner.Priority = bannerDto.Priority
+	if banner.Priority == 0 {
+		banner.Priority = 1
+	}
+	return nil
+}
+
+// parseReportRange reads the days of a report range. An empty to is today in the
+// location and an empty from the start of the default range ending on to.
+func parseReportRange(from string, to string, location *time.Location) (string, string, error) {
+	end := time.Now().In(location)
+	end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	if to != "" {
+		parsed, err := time.Parse(bannerReportDayLayout, to)
+		if err != nil {
+			return "", "", ErrInvalidReportRange
+		}
+		end = parsed
+	}
+	start := end.AddDate(0, 0, 1-defaultBannerReportDays)
+	if from != "" {
+		parsed, err := time.Parse(bannerReportDayLayout, from)
+		if err != nil {
+			return "", "", ErrInvalidReportRange
+		}
+		start = parsed
+	}
+	if start.After(end) || end.Sub(start) >= maxBannerReportDays*24*time.Hour {
+		return "", "", ErrInvalidReportRange
+	}
+	return start.Format(bannerReportDayLayout), end.Format(bannerReportDayLayout), nil
+}
+
+// clickThroughRate divides clicks by impressions, rounded to four decimals
+func clickThroughRate(clicks int64, impressions int64) float64 {
+	if impressions == 0 {
+		return 0
+	}
+	return math.Round(float64(clicks)/float64(impressions)*10000) / 10000
+}
+
+// parseBannerTime reads a campaign time, either RFC 3339 or local to the location; empty
+// values leave the campaign open
+func parseBannerTime(value string, location *time.Location) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		parsed = parsed.UTC()
+		return &parsed, nil
+	}
+	for _, layout := range bannerLocalTimeLayouts {
+		if parsed, err := time.ParseInLocation(layout, value, location); err == nil {
+			parsed = parsed.UTC()
+			return &parsed, nil
+		}
+	}
+	return nil, ErrInvalidBannerSchedule
 }
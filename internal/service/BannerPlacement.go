@@ -0,0 +1,71 @@
+package service
+
+import (
+	"math/rand"
+	"yp-blog-api/internal/models"
+)
+
+// defaultBannerEvery is how many blog cards come before each banner by default
+const defaultBannerEvery = 3
+
+// BannerPlacement decides where banners go in a listing of blog cards
+type BannerPlacement struct {
+	// Every is how many blog cards come before each banner; it defaults to 3
+	Every int
+	// MaxPerPage caps the banners in one listing, 0 for no cap
+	MaxPerPage int
+	// Trailing lets a banner end the listing; by default the last card is always a blog
+	Trailing bool
+}
+
+// slots tells how many banners fit in a listing of the given number of blog cards
+func (p BannerPlacement) slots(blogCount int) int {
+	every := p.every()
+	slots := blogCount / every
+	if !p.Trailing && slots > 0 && blogCount%every == 0 {
+		slots--
+	}
+	if p.MaxPerPage > 0 && slots > p.MaxPerPage {
+		slots = p.MaxPerPage
+	}
+	return slots
+}
+
+func (p BannerPlacement) every() int {
+	if p.Every <= 0 {
+		return defaultBannerEvery
+	}
+	return p.Every
+}
+
+// pickWeightedBanners draws up to count distinct banners at random, each draw picking a
+// banner with a chance proportional to its priority among the banners still left
+func pickWeightedBanners(banners []models.AdvertisingBanner, count int) []models.AdvertisingBanner {
+	left := make([]models.AdvertisingBanner, len(banners))
+	copy(left, banners)
+	var picked []models.AdvertisingBanner
+	for len(picked) < count && len(left) > 0 {
+		total := 0
+		for _, banner := range left {
+			total += bannerWeight(banner)
+		}
+		draw := rand.Intn(total)
+		for i, banner := range left {
+			draw -= bannerWeight(banner)
+			if draw < 0 {
+				picked = append(picked, banner)
+				left = append(left[:i], left[i+1:]...)
+				break
+			}
+		}
+	}
+	return picked
+}
+
+// bannerWeight is the priority of a banner; banners saved before priorities count once
+func bannerWeight(banner models.AdvertisingBanner) int {
+	if banner.Priority < 1 {
+		return 1
+	}
+	return banner.Priority
+}
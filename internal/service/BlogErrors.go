@@ -0,0 +1,32 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+var (
+	// ErrBlogNotFound is returned when no published blog matches the requested author and slug
+	ErrBlogNotFound = errors.New("blog not found")
+	// ErrInvalidSlug is returned when a slug chosen by the author is not well formed
+	ErrInvalidSlug = errors.New("slug must be at most 200 characters of lowercase letters and digits separated by single hyphens")
+	// ErrSlugConflict is returned when no free slug could be found for a blog
+	ErrSlugConflict = errors.New("slug is already in use by another blog")
+)
+
+// BlogMovedError is returned when a blog is requested by a previous author name or
+// slug. It carries the canonical location the client should be redirected to.
+type BlogMovedError struct {
+	Author string
+	Slug   string
+}
+
+func (e *BlogMovedError) Error() string {
+	return fmt.Sprintf("blog has moved to @%s/%s", e.Author, e.Slug)
+}
+
+// Location returns the canonical path of the blog
+func (e *BlogMovedError) Location() string {
+	return fmt.Sprintf("/api/blogs/@%s/%s", url.PathEscape(e.Author), url.PathEscape(e.Slug))
+}
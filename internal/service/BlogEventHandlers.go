@@ -0,0 +1,52 @@
+package service
+
+import (
+	"yp-blog-api/internal/models"
+	"yp-blog-api/internal/pubsub"
+)
+
+// registerEventHandlers subscribes the side effects of blog changes to the outbox
+func (s *blogServiceImpl) registerEventHandlers() {
+	for _, eventType := range []string{models.EventBlogPublished, models.EventBlogUpdated, models.EventBlogDeleted} {
+		s.outbox.Register(eventType, "webhooks", s.handleBlogWebhooks)
+	}
+	s.outbox.Register(models.EventBlogPublished, "notifications", s.handleNewPostNotifications)
+	s.outbox.Register(models.EventBlogPublished, "stream", s.handleNewPostStream)
+}
+
+// handleBlogWebhooks queues the event for the webhooks subscribed to it
+func (s *blogServiceImpl) handleBlogWebhooks(event models.OutboxEvent) error {
+	blog, err := s.blogRepo.FindByOutboxEvent(event)
+	if err != nil {
+		return err
+	}
+	return s.webhooks.Enqueue(event.Type, blog)
+}
+
+// handleNewPostNotifications tells the author's followers about a newly published blog
+func (s *blogServiceImpl) handleNewPostNotifications(event models.OutboxEvent) error {
+	blog, err := s.blogRepo.FindByOutboxEvent(event)
+	if err != nil {
+		return err
+	}
+	return s.notifier.Deliver(NewPostEvent{Blog: blog})
+}
+
+// handleNewPostStream streams the card of a newly published blog to the readers of
+// the site, of its author and of its categories
+func (s *blogServiceImpl) handleNewPostStream(event models.OutboxEvent) error {
+	blog, err := s.blogRepo.FindByOutboxEvent(event)
+	if err != nil {
+		return err
+	}
+	topics := []string{pubsub.TopicPosts, pubsub.AuthorTopic(blog.Author.UserName)}
+	for _, category := range blog.Categories {
+		topics = append(topics, pubsub.CategoryTopic(category.Slug))
+	}
+	s.bus.Publish(pubsub.Event{
+		Type:   StreamEventNewPost,
+		Topics: topics,
+		Data:   s.ToBlogCards([]models.Blog{blog}, nil)[0],
+	})
+	return nil
+}
@@ -12,16 +12,19 @@ type BlogService interface {
 	FindAll() ([]models.Blog, error)
 	Update(blog models.Blog) (models.Blog, error)
 
-	FindBlogCardByCategoriesSlug(slug string) []interface{}
-	FindBlogDetailByAuthorAndSlug(author string, slug string) (dto2.BlogDetailDto, error)
-	Find6BlogsByUsernameAndCountViewer(username string) []dto2.BlogCardDto
-	Find6BlogsByCategoriesSlug(slug string) []dto2.BlogCardDto
-	CreateBlog(blogCreateRequestDto dto2.BlogCreateRequestDto) error
+	FindBlogCardByCategoriesSlug(slug string, sortBy string, viewerID *uint) []interface{}
+	FindBlogDetailByAuthorAndSlug(author string, slug string, viewerID *uint) (dto2.BlogDetailDto, error)
+	Find6BlogsByUsernameAndCountViewer(username string, viewerID *uint) []dto2.BlogCardDto
+	Find6BlogsByCategoriesSlug(slug string, viewerID *uint) []dto2.BlogCardDto
+	FindRelatedBlogs(author string, slug string, viewerID *uint) ([]dto2.BlogCardDto, error)
+	ToBlogCards(blogs []models.Blog, viewerID *uint) []dto2.BlogCardDto
+	CreateBlog(blogCreateRequestDto dto2.BlogCreateRequestDto, authorID *uint) error
 	DeleteById(id uint) error
 
-	UpdateBlog(blogUpdateRequestDto dto2.BlogUpdateRequestDto, slug string) error
+	UpdateBlog(blogUpdateRequestDto dto2.BlogUpdateRequestDto, author string, slug string) error
 	DeleteBlogByChangeStatus(id uint) error
 	FindAllBlogForAdmin() ([]dto2.BlogAdminDto, error)
 
 	FindRecentPosts() ([]dto2.RecentPostBlogDto, error)
+	FlushViewCounts() error
 }
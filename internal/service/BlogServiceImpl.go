@@ -3,50 +3,102 @@ package service
 import (
 	"errors"
 	"fmt"
-	"math/rand"
+	"log"
+	"math"
+	"sort"
 	"strings"
 	"sync"
 	_ "sync"
+	"sync/atomic"
 	"time"
 	dto2 "yp-blog-api/internal/dto"
 	mapper2 "yp-blog-api/internal/mapping"
 	"yp-blog-api/internal/models"
+	"yp-blog-api/internal/pubsub"
 	_ "yp-blog-api/internal/repository"
 	repositories2 "yp-blog-api/internal/repository"
 	"yp-blog-api/internal/utils"
 )
 
+// BlogSortMostLiked orders blog cards by their total number of reactions
+const BlogSortMostLiked = "most-liked"
+
+const (
+	// summaryMaxLength mirrors the max length accepted for Summary in the request DTOs
+	summaryMaxLength = 500
+	// maxSlugCandidates is how many numbered variants of a slug are tried before giving up
+	maxSlugCandidates = 20
+
+	// Related posts are ranked from a pool of candidates sharing a tag or category
+	relatedBlogsLimit          = 6
+	relatedCandidatePoolSize   = 100
+	relatedTagWeight           = 0.4
+	relatedCategoryWeight      = 0.25
+	relatedTitleWeight         = 0.2
+	relatedRecencyWeight       = 0.15
+	relatedRecencyHalfLifeDays = 90.0
+)
+
+// viewMilestones are the view counts authors are notified about
+var viewMilestones = []int{100, 1000, 10000, 100000, 1000000}
+
 // blogServiceImpl implements the BlogService interface.
 type blogServiceImpl struct {
 	blogRepo     repositories2.BlogRepository
 	tagRepo      repositories2.TagRepository
 	categoryRepo repositories2.CategoryRepository
+	commentRepo  repositories2.CommentRepository
+	reactions    ReactionService
+	bookmarkRepo repositories2.BookmarkRepository
+	followRepo   repositories2.FollowRepository
+	notifier     NotificationService
+	bus          *pubsub.Bus
+	webhooks     WebhookService
+	outbox       OutboxDispatcher
+	media        MediaService
 	bannerRepo   *repositories2.AdvertisingBannerRepository
 	blogMapper   mapper2.BlogMapper
 	bannerMapper mapper2.AdvertisingBannerMapper
+	placement    BannerPlacement
 	viewCountMap sync.Map // Thread-safe map for storing view counts
 
 }
 
 // NewBlogService creates a new instance of blogServiceImpl
-func NewBlogService(blogRepo repositories2.BlogRepository, bannerRepo *repositories2.AdvertisingBannerRepository, blogMapper mapper2.BlogMapper, bannerMapper mapper2.AdvertisingBannerMapper, categoryRepo repositories2.CategoryRepository, TagRepo repositories2.TagRepository) *blogServiceImpl {
-	return &blogServiceImpl{
+func NewBlogService(blogRepo repositories2.BlogRepository, bannerRepo *repositories2.AdvertisingBannerRepository, blogMapper mapper2.BlogMapper, bannerMapper mapper2.AdvertisingBannerMapper, categoryRepo repositories2.CategoryRepository, TagRepo repositories2.TagRepository, commentRepo repositories2.CommentRepository, reactions ReactionService, bookmarkRepo repositories2.BookmarkRepository, followRepo repositories2.FollowRepository, notifier NotificationService, bus *pubsub.Bus, webhooks WebhookService, outbox OutboxDispatcher, media MediaService, placement BannerPlacement) *blogServiceImpl {
+	s := &blogServiceImpl{
 		blogRepo:     blogRepo,
 		bannerRepo:   bannerRepo,
 		blogMapper:   blogMapper,
 		bannerMapper: bannerMapper,
 		categoryRepo: categoryRepo,
 		tagRepo:      TagRepo,
+		commentRepo:  commentRepo,
+		reactions:    reactions,
+		bookmarkRepo: bookmarkRepo,
+		followRepo:   followRepo,
+		notifier:     notifier,
+		bus:          bus,
+		webhooks:     webhooks,
+		outbox:       outbox,
+		media:        media,
+		placement:    placement,
 		viewCountMap: sync.Map{}, // Initialize sync.Map
 
 	}
+	s.registerEventHandlers()
+	return s
 }
 
-func (s *blogServiceImpl) FindBlogCardByCategoriesSlug(slug string) []interface{} {
+func (s *blogServiceImpl) FindBlogCardByCategoriesSlug(slug string, sortBy string, viewerID *uint) []interface{} {
 	var blogs []models.Blog
 	var err error
 
-	if slug == "" || slug == "ALL" {
+	// "ALL" is the listing of all categories, which banners know as the empty slug
+	if slug == "ALL" {
+		slug = ""
+	}
+	if slug == "" {
 		blogs, err = s.blogRepo.FindAllByPublishedAndNotDeletedOrderByCountViewerDescCreatedAtDesc()
 	} else {
 		blogs, err = s.blogRepo.FindBlogsByCategorySlug(slug)
@@ -57,23 +109,94 @@ func (s *blogServiceImpl) FindBlogCardByCategoriesSlug(slug string) []interface{
 		return []interface{}{}
 	}
 
-	blogCardDtos := s.blogMapper.BlogToBlogCardDto(blogs)
+	blogCardDtos := s.ToBlogCards(blogs, viewerID)
+
+	// Reorder by total reactions, keeping the repository order between equally liked blogs
+	if sortBy == BlogSortMostLiked {
+		sort.SliceStable(blogCardDtos, func(i, j int) bool {
+			return blogCardDtos[i].ReactionCount > blogCardDtos[j].ReactionCount
+		})
+	}
+
+	// Listings too short to hold a banner skip the banners altogether
+	slots := s.placement.slots(len(blogCardDtos))
+	if slots == 0 {
+		return s.convertBlogCardsToInterface(blogCardDtos)
+	}
 
-	banners, err := s.bannerRepo.FindAllByIsDeletedIsFalse()
+	// Only banners within their campaign, below their daily cap and targeted at the
+	// listed category are shown
+	now := time.Now()
+	activeBanners, err := s.bannerRepo.FindAllActive(now)
 	if err != nil {
 		// Handle the error, possibly log it and return blog cards only
 		return s.convertBlogCardsToInterface(blogCardDtos)
 	}
+	var banners []models.AdvertisingBanner
+	for _, banner := range activeBanners {
+		if banner.TargetsCategory(slug) {
+			banners = append(banners, banner)
+		}
+	}
+
+	// Banners with a higher priority are picked more often; only the picked ones are seen
+	banners = pickWeightedBanners(banners, slots)
+	if err := s.bannerRepo.IncrementImpressions(banners, now); err != nil {
+		log.Printf("Error occurred while counting banner impressions: %v", err)
+	}
 
 	bannerDtos := s.bannerMapper.AdvertisingBannerListToDtoList(banners)
+	return interleaveBlogsAndBanners(blogCardDtos, bannerDtos, s.placement.every())
+}
 
-	// Shuffle banners
-	rand.Seed(time.Now().UnixNano())
-	rand.Shuffle(len(bannerDtos), func(i, j int) {
-		bannerDtos[i], bannerDtos[j] = bannerDtos[j], bannerDtos[i]
-	})
+// ToBlogCards maps blogs to blog cards and fills in their comment and reaction counts.
+// When viewerID is set, the cards also tell whether the viewer bookmarked the blog.
+func (s *blogServiceImpl) ToBlogCards(blogs []models.Blog, viewerID *uint) []dto2.BlogCardDto {
+	blogCardDtos := s.blogMapper.BlogToBlogCardDto(blogs)
+
+	blogIds := make([]uint, len(blogs))
+	for i, blog := range blogs {
+		blogIds[i] = blog.ID
+	}
 
-	return interleaveBlogsAndBanners(blogCardDtos, bannerDtos)
+	// Counts are decorative; keep serving the cards without them on failure
+	commentCounts, err := s.commentRepo.CountVisibleByBlogIds(blogIds)
+	if err != nil {
+		log.Printf("Error occurred while counting comments: %v", err)
+	}
+	reactionCounts, err := s.reactions.CountByBlogIds(blogIds)
+	if err != nil {
+		log.Printf("Error occurred while counting reactions: %v", err)
+	}
+	var bookmarked map[uint]bool
+	if viewerID != nil {
+		if bookmarked, err = s.bookmarkRepo.FindBookmarkedBlogIds(*viewerID, blogIds); err != nil {
+			log.Printf("Error occurred while loading bookmarks: %v", err)
+		}
+	}
+	thumbnails, err := s.media.FindThumbnailImages(blogIds)
+	if err != nil {
+		log.Printf("Error occurred while loading thumbnails: %v", err)
+	}
+	for i := range blogCardDtos {
+		blogCardDtos[i].ThumbnailImage = thumbnails[blogs[i].ID]
+		blogCardDtos[i].CommentCount = commentCounts[blogs[i].ID]
+		blogCardDtos[i].Reactions, blogCardDtos[i].ReactionCount = reactionTotals(reactionCounts[blogs[i].ID])
+		blogCardDtos[i].Bookmarked = bookmarked[blogs[i].ID]
+	}
+	return blogCardDtos
+}
+
+// reactionTotals returns the per-type reaction counts of a blog, never nil, and their sum
+func reactionTotals(counts map[string]int64) (map[string]int64, int64) {
+	if counts == nil {
+		counts = map[string]int64{}
+	}
+	var total int64
+	for _, count := range counts {
+		total += count
+	}
+	return counts, total
 }
 
 // convertBlogCardsToInterface converts a slice of BlogCardDto to a slice of empty interfaces
@@ -85,34 +208,32 @@ func (s *blogServiceImpl) convertBlogCardsToInterface(blogCards []dto2.BlogCardD
 	return result
 }
 
-// interleaveBlogsAndBanners interleaves blog cards and banners
-func interleaveBlogsAndBanners(blogs []dto2.BlogCardDto, banners []dto2.AdvertisingBannerDto) []interface{} {
-	var result []interface{}
+// interleaveBlogsAndBanners puts a banner after every few blog cards until the banners
+// run out; the caller picks as many banners as there are slots for
+func interleaveBlogsAndBanners(blogs []dto2.BlogCardDto, banners []dto2.AdvertisingBannerDto, every int) []interface{} {
+	result := make([]interface{}, 0, len(blogs)+len(banners))
 	bannerIndex := 0
 	bannerCount := len(banners)
 
 	for i := 0; i < len(blogs); i++ {
 		result = append(result, blogs[i])
-		// Add a banner after every few blogs (e.g., every 3 blogs)
-		if (i+1)%3 == 0 && bannerIndex < bannerCount {
+		if (i+1)%every == 0 && bannerIndex < bannerCount {
 			result = append(result, banners[bannerIndex])
 			bannerIndex++
 		}
 	}
 
-	// Add any remaining banners if necessary
-	for bannerIndex < bannerCount {
-		result = append(result, banners[bannerIndex])
-		bannerIndex++
-	}
-
 	return result
 }
 
-func (s *blogServiceImpl) FindBlogDetailByAuthorAndSlug(author string, slug string) (dto2.BlogDetailDto, error) {
+func (s *blogServiceImpl) FindBlogDetailByAuthorAndSlug(author string, slug string, viewerID *uint) (dto2.BlogDetailDto, error) {
 	// Fetch the blog by author and slug
 	blog, err := s.blogRepo.FindByUsernameAndSlug(author, slug)
 	if err != nil {
+		// The author or the slug may have been renamed; point the client at the current URL
+		if moved, historyErr := s.blogRepo.FindByPreviousUsernameOrSlug(author, slug); historyErr == nil {
+			return dto2.BlogDetailDto{}, &BlogMovedError{Author: moved.Author.UserName, Slug: moved.Slug}
+		}
 		return dto2.BlogDetailDto{}, fmt.Errorf("blog by author '%s' and slug '%s' could not be found: %w", author, slug, err)
 	}
 
@@ -122,25 +243,87 @@ func (s *blogServiceImpl) FindBlogDetailByAuthorAndSlug(author string, slug stri
 		return dto2.BlogDetailDto{}, fmt.Errorf("failed to increment view count: %w", err)
 	}
 
+	// Readers watching the blog see the views that are not flushed yet as well
+	s.bus.Publish(pubsub.Event{
+		Type:   StreamEventViewCount,
+		Topics: []string{pubsub.BlogTopic(blog.Author.UserName, blog.Slug)},
+		Data: dto2.ViewCountDto{Author: blog.Author.UserName, Slug: blog.Slug,
+			Views: blog.CountViewer + s.pendingViewCount(int(blog.ID))},
+	})
+
 	// Map the Blog entity to BlogDetailDto
 	blogDetail := s.blogMapper.BlogToBlogDetailDto(blog)
+
+	reactionCounts, err := s.reactions.CountByBlogIds([]uint{blog.ID})
+	if err != nil {
+		log.Printf("Error occurred while counting reactions: %v", err)
+	}
+	blogDetail.Reactions, blogDetail.ReactionCount = reactionTotals(reactionCounts[blog.ID])
+	thumbnails, err := s.media.FindThumbnailImages([]uint{blog.ID})
+	if err != nil {
+		log.Printf("Error occurred while loading thumbnails: %v", err)
+	}
+	blogDetail.ThumbnailImage = thumbnails[blog.ID]
+	if err := fillFollowStats(s.followRepo, &blogDetail.Author, blog.AuthorID, viewerID); err != nil {
+		log.Printf("Error occurred while counting followers: %v", err)
+	}
 	return blogDetail, nil
 }
 
-// IncrementViewCount increments the view count for the given blog ID
+// IncrementViewCount increments the view count for the given blog ID. Views are
+// kept in memory until FlushViewCounts writes them to the database.
 func (s *blogServiceImpl) IncrementViewCount(id int) error {
-	// Load or store the initial value if it doesn't exist
-	value, _ := s.viewCountMap.LoadOrStore(id, 1)
+	// Load or store the initial counter if it doesn't exist
+	value, _ := s.viewCountMap.LoadOrStore(id, new(int64))
 
-	// Increment the value
-	if currentCount, ok := value.(int); ok {
-		s.viewCountMap.Store(id, currentCount+1)
+	// Increment the counter
+	if counter, ok := value.(*int64); ok {
+		atomic.AddInt64(counter, 1)
 		return nil
 	}
 	return fmt.Errorf("failed to increment view count for blog ID: %d", id)
 }
 
-func (s *blogServiceImpl) CreateBlog(blogCreateRequestDto dto2.BlogCreateRequestDto) error {
+// pendingViewCount returns the views of a blog counted in memory since the last flush
+func (s *blogServiceImpl) pendingViewCount(id int) int {
+	if value, ok := s.viewCountMap.Load(id); ok {
+		return int(atomic.LoadInt64(value.(*int64)))
+	}
+	return 0
+}
+
+// FlushViewCounts adds the views counted in memory to the stored view counts and
+// tells authors about the milestones their blogs reached. Views that could not be
+// written are kept for the next flush.
+func (s *blogServiceImpl) FlushViewCounts() error {
+	var flushErr error
+	s.viewCountMap.Range(func(key, value interface{}) bool {
+		counter := value.(*int64)
+		pending := atomic.SwapInt64(counter, 0)
+		if pending == 0 {
+			return true
+		}
+
+		blog, err := s.blogRepo.IncrementCountViewer(uint(key.(int)), int(pending))
+		if err != nil {
+			atomic.AddInt64(counter, pending)
+			flushErr = fmt.Errorf("failed to flush view count for blog ID %d: %w", key, err)
+			return true
+		}
+
+		previous := blog.CountViewer - int(pending)
+		for _, milestone := range viewMilestones {
+			if previous < milestone && blog.CountViewer >= milestone {
+				s.notifier.Publish(ViewMilestoneEvent{Blog: blog, Milestone: milestone})
+			}
+		}
+		return true
+	})
+	return flushErr
+}
+
+// CreateBlog creates a blog written by the user with the given authorID, when known
+func (s *blogServiceImpl) CreateBlog(blogCreateRequestDto dto2.BlogCreateRequestDto, authorID *uint) error {
 	// Validate the incoming DTO
 	if err := blogCreateRequestDto.Validate(); err != nil {
 		return fmt.Errorf("validation error: %v", err)
@@ -148,6 +331,14 @@ func (s *blogServiceImpl) CreateBlog(blogCreateRequestDto dto2.BlogCreateRequest
 
 	// Map the DTO to the Blog entity
 	blog := s.blogMapper.CreateBlogDtoToBlog(blogCreateRequestDto)
+	if authorID != nil {
+		blog.AuthorID = *authorID
+	}
+
+	// Fall back to an extracted summary when the author did not write one
+	if strings.TrimSpace(blog.Summary) == "" {
+		applyAutoSummary(&blog)
+	}
 
 	// Retrieve categories by IDs
 	categories, err := s.categoryRepo.FindAllById(blogCreateRequestDto.CategoryIds)
@@ -156,32 +347,22 @@ func (s *blogServiceImpl) CreateBlog(blogCreateRequestDto dto2.BlogCreateRequest
 	}
 	blog.Categories = categories
 
-	// Concatenate category slugs for the slug generation
-	var categoryNames []string
-	for _, category := range categories {
-		categoryNames = append(categoryNames, strings.ReplaceAll(strings.ToLower(category.Slug), " ", "-"))
+	// Use the slug chosen by the author, or derive one from the title and categories
+	baseSlug := strings.TrimSpace(blogCreateRequestDto.Slug)
+	generated := baseSlug == ""
+	if generated {
+		baseSlug = generateBaseSlug(blogCreateRequestDto.BlogTitle, categories)
+	} else if !utils.IsValidSlug(baseSlug) {
+		return ErrInvalidSlug
 	}
-
-	// Generate a 9-digit unique identifier
-	uniqueIdentifier := utils.GenerateUniqueIdentifier()
-
-	// Prepare the blog title for slug generation
-	nameBlog := blogCreateRequestDto.BlogTitle
-
-	// Check if the blog title contains Khmer characters
-	if utils.ContainsKhmer(nameBlog) {
-		nameBlog = utils.RemoveKhmerCharacters(nameBlog)
+	slug, err := s.resolveSlug(baseSlug, blog.AuthorID, 0, generated)
+	if err != nil {
+		return err
 	}
-
-	// Concatenate title and categories for the slug
-	titleAndCategories := nameBlog + "-" + strings.Join(categoryNames, "-")
-
-	// Generate a descriptive slug using the slug package and append the unique identifier
-	slug := utils.Init(titleAndCategories + "-" + uniqueIdentifier)
 	blog.Slug = slug
 
 	// Check the pinned blogs limit
-	if err := s.checkPinnedBlogsLimit(int(blog.Author.ID), blog.IsPin); err != nil {
+	if err := s.checkPinnedBlogsLimit(int(blog.AuthorID), blog.IsPin); err != nil {
 		return err
 	}
 
@@ -192,15 +373,80 @@ func (s *blogServiceImpl) CreateBlog(blogCreateRequestDto dto2.BlogCreateRequest
 	}
 	blog.Tags = tags
 
-	// Save the blog and check for errors
-	_, err = s.blogRepo.Save(blog)
+	// Save the blog and check for errors; subscribers hear about it once it is published
+	var events []models.OutboxEvent
+	if blog.Published {
+		events = append(events, models.OutboxEvent{Type: models.EventBlogPublished})
+	}
+	blog, err = s.blogRepo.SaveWithEvents(blog, events)
 	if err != nil {
+		// Another blog may have claimed the slug since it was resolved
+		if errors.Is(err, repositories2.ErrSlugTaken) {
+			return ErrSlugConflict
+		}
 		return fmt.Errorf("error saving blog: %v", err)
 	}
 
+	s.outbox.Notify()
+	s.syncMediaReferences(blog)
 	return nil
 }
 
+// generateBaseSlug builds a descriptive slug from the blog title and its category slugs
+func generateBaseSlug(title string, categories []models.Category) string {
+	// Concatenate category slugs for the slug generation
+	var categoryNames []string
+	for _, category := range categories {
+		categoryNames = append(categoryNames, strings.ReplaceAll(strings.ToLower(category.Slug), " ", "-"))
+	}
+
+	// Check if the blog title contains Khmer characters
+	if utils.ContainsKhmer(title) {
+		title = utils.RemoveKhmerCharacters(title)
+	}
+
+	// Concatenate title and categories for the slug
+	baseSlug := utils.Init(title + "-" + strings.Join(categoryNames, "-"))
+	if len(baseSlug) > utils.MaxSlugLength {
+		baseSlug = strings.TrimRight(baseSlug[:utils.MaxSlugLength], "-")
+	}
+	if baseSlug == "" {
+		baseSlug = "post"
+	}
+	return baseSlug
+}
+
+// resolveSlug returns the first slug among baseSlug, baseSlug-2, baseSlug-3 and so on that
+// the author has not used yet, ignoring the blog being updated. Generated slugs fall back to a random identifier
+// when every numbered candidate is taken; slugs chosen by the author report a conflict.
+func (s *blogServiceImpl) resolveSlug(baseSlug string, authorId uint, blogId uint, generated bool) (string, error) {
+	candidates := make([]string, 0, maxSlugCandidates+1)
+	candidates = append(candidates, baseSlug)
+	for n := 2; n <= maxSlugCandidates; n++ {
+		candidates = append(candidates, fmt.Sprintf("%s-%d", baseSlug, n))
+	}
+	if generated {
+		candidates = append(candidates, baseSlug+"-"+utils.GenerateUniqueIdentifier())
+	}
+
+	for _, candidate := range candidates {
+		taken, err := s.blogRepo.ExistsBySlug(candidate, authorId, blogId)
+		if err != nil {
+			return "", fmt.Errorf("error checking slug availability: %v", err)
+		}
+		if !taken {
+			return candidate, nil
+		}
+	}
+	return "", ErrSlugConflict
+}
+
+// applyAutoSummary fills the blog summary with an extractive summary of its content
+func applyAutoSummary(blog *models.Blog) {
+	blog.Summary = utils.GenerateSummary(blog.BlogContent, summaryMaxLength)
+	blog.SummaryAutoGenerated = blog.Summary != ""
+}
+
 func (s *blogServiceImpl) checkPinnedBlogsLimit(authorID int, isPin bool) error {
 	// Example check: Limit to 3 pinned blogs per author
 	if isPin {
@@ -246,7 +492,11 @@ func (s *blogServiceImpl) Update(blog models.Blog) (models.Blog, error) {
 
 // DeleteById deletes a blog by its ID.
 func (s *blogServiceImpl) DeleteById(id uint) error {
-	return s.blogRepo.DeleteById(id)
+	if err := s.blogRepo.DeleteById(id); err != nil {
+		return err
+	}
+	s.outbox.Notify()
+	return nil
 }
 
 func (s *blogServiceImpl) FindAllBlogForAdmin() ([]dto2.BlogAdminDto, error) {
@@ -259,7 +509,7 @@ func (s *blogServiceImpl) FindAllBlogForAdmin() ([]dto2.BlogAdminDto, error) {
 	return blogDtos, nil
 }
 
-func (s *blogServiceImpl) Find6BlogsByUsernameAndCountViewer(username string) []dto2.BlogCardDto {
+func (s *blogServiceImpl) Find6BlogsByUsernameAndCountViewer(username string, viewerID *uint) []dto2.BlogCardDto {
 	blogs, err := s.blogRepo.FindRandom6ByUsername(username)
 	if err != nil {
 		// Handle the error, possibly log it and return an empty list
@@ -267,12 +517,12 @@ func (s *blogServiceImpl) Find6BlogsByUsernameAndCountViewer(username string) []
 	}
 
 	// Use the mapper to convert the blogs to BlogCardDto
-	blogCardDtos := s.blogMapper.BlogToBlogCardDto(blogs)
+	blogCardDtos := s.ToBlogCards(blogs, viewerID)
 
 	return blogCardDtos
 }
 
-func (s *blogServiceImpl) Find6BlogsByCategoriesSlug(slug string) []dto2.BlogCardDto {
+func (s *blogServiceImpl) Find6BlogsByCategoriesSlug(slug string, viewerID *uint) []dto2.BlogCardDto {
 	blogs, err := s.blogRepo.FindTop6ByCategorySlug(slug)
 	if err != nil {
 		// Handle the error, possibly log it and return an empty list
@@ -280,31 +530,142 @@ func (s *blogServiceImpl) Find6BlogsByCategoriesSlug(slug string) []dto2.BlogCar
 	}
 
 	// Use the mapper to convert the blogs to BlogCardDto
-	blogCardDtos := s.blogMapper.BlogToBlogCardDto(blogs)
+	blogCardDtos := s.ToBlogCards(blogs, viewerID)
 
 	return blogCardDtos
 }
-func (s *blogServiceImpl) UpdateBlog(blogUpdateRequestDto dto2.BlogUpdateRequestDto, slug string) error {
+
+// FindRelatedBlogs ranks published blogs by tag and category overlap, title
+// similarity and recency relative to the blog identified by author and slug.
+func (s *blogServiceImpl) FindRelatedBlogs(author string, slug string, viewerID *uint) ([]dto2.BlogCardDto, error) {
+	blog, err := s.blogRepo.FindByUsernameAndSlug(author, slug)
+	if err != nil {
+		return nil, fmt.Errorf("blog by author '%s' and slug '%s' could not be found: %w", author, slug, err)
+	}
+
+	candidates, err := s.blogRepo.FindRelatedCandidates(blog, relatedCandidatePoolSize)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	scores := make(map[uint]float64, len(candidates))
+	for _, candidate := range candidates {
+		scores[candidate.ID] = relatedScore(blog, candidate, now)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return scores[candidates[i].ID] > scores[candidates[j].ID]
+	})
+	if len(candidates) > relatedBlogsLimit {
+		candidates = candidates[:relatedBlogsLimit]
+	}
+
+	return s.ToBlogCards(candidates, viewerID), nil
+}
+
+// relatedScore combines tag and category Jaccard overlap, title token overlap and a
+// recency decay into a single relevance score between 0 and 1
+func relatedScore(blog models.Blog, candidate models.Blog, now time.Time) float64 {
+	tagIds := make(map[uint]bool)
+	candidateTagIds := make(map[uint]bool)
+	for _, tag := range blog.Tags {
+		tagIds[tag.ID] = true
+	}
+	for _, tag := range candidate.Tags {
+		candidateTagIds[tag.ID] = true
+	}
+	categoryIds := make(map[uint]bool)
+	candidateCategoryIds := make(map[uint]bool)
+	for _, category := range blog.Categories {
+		categoryIds[category.ID] = true
+	}
+	for _, category := range candidate.Categories {
+		candidateCategoryIds[category.ID] = true
+	}
+
+	ageDays := now.Sub(candidate.CreatedAt).Hours() / 24
+	if ageDays < 0 {
+		ageDays = 0
+	}
+	recency := math.Pow(0.5, ageDays/relatedRecencyHalfLifeDays)
+
+	return relatedTagWeight*jaccard(tagIds, candidateTagIds) +
+		relatedCategoryWeight*jaccard(categoryIds, candidateCategoryIds) +
+		relatedTitleWeight*jaccard(utils.TextTokens(blog.BlogTitle), utils.TextTokens(candidate.BlogTitle)) +
+		relatedRecencyWeight*recency
+}
+
+// jaccard returns the size of the intersection of two sets over the size of their union
+func jaccard[K comparable](a, b map[K]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	intersection := 0
+	for key := range a {
+		if b[key] {
+			intersection++
+		}
+	}
+	return float64(intersection) / float64(len(a)+len(b)-intersection)
+}
+
+func (s *blogServiceImpl) UpdateBlog(blogUpdateRequestDto dto2.BlogUpdateRequestDto, author string, slug string) error {
 	// Validate the DTO
 	if err := blogUpdateRequestDto.Validate(); err != nil {
 		return err
 	}
 
-	// Fetch the existing blog by slug
-	blog, err := s.blogRepo.FindBySlug(slug)
+	// Fetch the existing blog; slugs are only unique per author
+	blog, err := s.blogRepo.FindByUsernameAndSlug(author, slug)
 	if err != nil {
 		return errors.New("blog not found")
 	}
 
+	// Change the slug when the author picked a new one; the old slug keeps redirecting
+	newSlug := strings.TrimSpace(blogUpdateRequestDto.Slug)
+	if newSlug != "" && newSlug != blog.Slug {
+		if !utils.IsValidSlug(newSlug) {
+			return ErrInvalidSlug
+		}
+		resolved, err := s.resolveSlug(newSlug, blog.AuthorID, blog.ID, false)
+		if err != nil {
+			return err
+		}
+		blog.Slug = resolved
+	}
+
 	// Map the updated fields from the DTO to the Blog entity
+	wasPublished := blog.Published
+	previousSummary := blog.Summary
 	s.blogMapper.UpdateBlog(&blog, blogUpdateRequestDto)
 
-	// Save the updated blog
-	_, err = s.blogRepo.Save(blog)
+	// A summary written by the author overrides the generated one; otherwise keep
+	// the generated summary in sync with the new content. Editors send the summary
+	// they loaded back unchanged, which must not pin a generated summary.
+	submittedSummary := strings.TrimSpace(blogUpdateRequestDto.Summary)
+	if submittedSummary != "" && !(blog.SummaryAutoGenerated && submittedSummary == strings.TrimSpace(previousSummary)) {
+		blog.SummaryAutoGenerated = false
+	} else if blog.Summary == "" || blog.SummaryAutoGenerated {
+		applyAutoSummary(&blog)
+	}
+
+	// Save the updated blog; drafts that stay drafts are of no interest to subscribers
+	var events []models.OutboxEvent
+	if blog.Published && !wasPublished {
+		events = append(events, models.OutboxEvent{Type: models.EventBlogPublished})
+	} else if blog.Published || wasPublished {
+		events = append(events, models.OutboxEvent{Type: models.EventBlogUpdated})
+	}
+	blog, err = s.blogRepo.SaveWithEvents(blog, events)
 	if err != nil {
+		if errors.Is(err, repositories2.ErrSlugTaken) {
+			return ErrSlugConflict
+		}
 		return err
 	}
 
+	s.outbox.Notify()
+	s.syncMediaReferences(blog)
 	return nil
 }
 
@@ -316,12 +677,28 @@ func (s *blogServiceImpl) DeleteBlogByChangeStatus(id uint) error {
 	}
 
 	// Set IsDeleted to true
+	var events []models.OutboxEvent
+	if !blog.IsDeleted {
+		events = append(events, models.OutboxEvent{Type: models.EventBlogDeleted})
+	}
 	blog.IsDeleted = true
 
-	// Save the changes to the database using the Save method
-	if _, err := s.Save(blog); err != nil {
+	// Save the changes to the database together with the deletion event
+	if _, err := s.blogRepo.SaveWithEvents(blog, events); err != nil {
 		return err // Return an error if the save fails
 	}
 
+	s.outbox.Notify()
+	// Media used only by deleted blogs can be removed from the library
+	s.syncMediaReferences(blog)
+
 	return nil // Return nil if the operation was successful
 }
+
+// syncMediaReferences records the media a saved blog uses. The blog is saved already,
+// so a failure is only logged; the references are fixed by the blog's next save.
+func (s *blogServiceImpl) syncMediaReferences(blog models.Blog) {
+	if err := s.media.SyncBlogReferences(blog); err != nil {
+		log.Printf("Error occurred while recording the media used by blog %d: %v", blog.ID, err)
+	}
+}
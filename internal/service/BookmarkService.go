@@ -0,0 +1,254 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"yp-blog-api/internal/dto"
+	"yp-blog-api/internal/mapping"
+	"yp-blog-api/internal/models"
+	"yp-blog-api/internal/repository"
+)
+
+var (
+	ErrReadingListNotFound  = errors.New("reading list not found")
+	ErrReadingListNameTaken = errors.New("a reading list with this name already exists")
+)
+
+// BookmarkService manages the blogs a signed-in reader saved, either as plain
+// bookmarks or in named reading lists
+type BookmarkService interface {
+	FindBookmarks(userId uint) ([]dto.BlogCardDto, error)
+	AddBookmark(userId uint, author string, slug string) error
+	RemoveBookmark(userId uint, author string, slug string) error
+	ReorderBookmarks(userId uint, orderDto dto.BlogOrderRequestDto) ([]dto.BlogCardDto, error)
+
+	FindReadingLists(userId uint) ([]dto.ReadingListDto, error)
+	FindReadingList(userId uint, id uint) (*dto.ReadingListDetailDto, error)
+	CreateReadingList(userId uint, requestDto dto.ReadingListRequestDto) (*dto.ReadingListDto, error)
+	RenameReadingList(userId uint, id uint, requestDto dto.ReadingListRequestDto) (*dto.ReadingListDto, error)
+	DeleteReadingList(userId uint, id uint) error
+	AddToReadingList(userId uint, id uint, author string, slug string) error
+	RemoveFromReadingList(userId uint, id uint, author string, slug string) error
+	ReorderReadingList(userId uint, id uint, orderDto dto.BlogOrderRequestDto) (*dto.ReadingListDetailDto, error)
+}
+
+type bookmarkServiceImpl struct {
+	bookmarkRepo    repositories.BookmarkRepository
+	readingListRepo repositories.ReadingListRepository
+	blogRepo        repositories.BlogRepository
+	blogService     BlogService
+}
+
+// NewBookmarkService creates a BookmarkService; blogService renders the saved blogs as cards
+func NewBookmarkService(bookmarkRepo repositories.BookmarkRepository, readingListRepo repositories.ReadingListRepository, blogRepo repositories.BlogRepository, blogService BlogService) BookmarkService {
+	return &bookmarkServiceImpl{
+		bookmarkRepo:    bookmarkRepo,
+		readingListRepo: readingListRepo,
+		blogRepo:        blogRepo,
+		blogService:     blogService,
+	}
+}
+
+func (s *bookmarkServiceImpl) FindBookmarks(userId uint) ([]dto.BlogCardDto, error) {
+	blogs, err := s.bookmarkRepo.FindBlogsByUserId(userId)
+	if err != nil {
+		return nil, err
+	}
+	return s.blogService.ToBlogCards(blogs, &userId), nil
+}
+
+// AddBookmark bookmarks a blog; bookmarking it again has no further effect
+func (s *bookmarkServiceImpl) AddBookmark(userId uint, author string, slug string) error {
+	blog, err := s.findSavableBlog(author, slug)
+	if err != nil {
+		return err
+	}
+	return s.bookmarkRepo.CreateIfNotExists(&models.Bookmark{UserID: userId, BlogID: blog.ID})
+}
+
+// RemoveBookmark removes a bookmark; removing a missing bookmark has no effect
+func (s *bookmarkServiceImpl) RemoveBookmark(userId uint, author string, slug string) error {
+	blog, err := s.blogRepo.FindByUsernameAndSlug(author, slug)
+	if err != nil {
+		return fmt.Errorf("blog by author '%s' and slug '%s' could not be found: %w", author, slug, ErrBlogNotFound)
+	}
+	return s.bookmarkRepo.DeleteByUserIdAndBlogId(userId, blog.ID)
+}
+
+func (s *bookmarkServiceImpl) ReorderBookmarks(userId uint, orderDto dto.BlogOrderRequestDto) ([]dto.BlogCardDto, error) {
+	blogIds, err := s.resolveOrder(orderDto)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.bookmarkRepo.Reorder(userId, blogIds); err != nil {
+		return nil, err
+	}
+	return s.FindBookmarks(userId)
+}
+
+func (s *bookmarkServiceImpl) FindReadingLists(userId uint) ([]dto.ReadingListDto, error) {
+	readingLists, err := s.readingListRepo.FindAllByUserId(userId)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, len(readingLists))
+	for i, readingList := range readingLists {
+		ids[i] = readingList.ID
+	}
+	counts, err := s.readingListRepo.CountItemsByReadingListIds(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	readingListDtos := make([]dto.ReadingListDto, len(readingLists))
+	for i, readingList := range readingLists {
+		readingListDtos[i] = toReadingListDto(readingList, counts[readingList.ID])
+	}
+	return readingListDtos, nil
+}
+
+func (s *bookmarkServiceImpl) FindReadingList(userId uint, id uint) (*dto.ReadingListDetailDto, error) {
+	readingList, err := s.findOwnedReadingList(userId, id)
+	if err != nil {
+		return nil, err
+	}
+	blogs, err := s.readingListRepo.FindBlogsByReadingListId(readingList.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ReadingListDetailDto{
+		ReadingListDto: toReadingListDto(readingList, int64(len(blogs))),
+		Blogs:          s.blogService.ToBlogCards(blogs, &userId),
+	}, nil
+}
+
+func (s *bookmarkServiceImpl) CreateReadingList(userId uint, requestDto dto.ReadingListRequestDto) (*dto.ReadingListDto, error) {
+	readingList := models.ReadingList{UserID: userId}
+	return s.saveReadingList(readingList, requestDto)
+}
+
+func (s *bookmarkServiceImpl) RenameReadingList(userId uint, id uint, requestDto dto.ReadingListRequestDto) (*dto.ReadingListDto, error) {
+	readingList, err := s.findOwnedReadingList(userId, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.saveReadingList(readingList, requestDto)
+}
+
+func (s *bookmarkServiceImpl) DeleteReadingList(userId uint, id uint) error {
+	readingList, err := s.findOwnedReadingList(userId, id)
+	if err != nil {
+		return err
+	}
+	return s.readingListRepo.DeleteById(readingList.ID)
+}
+
+// AddToReadingList appends a blog to a reading list; adding it again has no further effect
+func (s *bookmarkServiceImpl) AddToReadingList(userId uint, id uint, author string, slug string) error {
+	readingList, err := s.findOwnedReadingList(userId, id)
+	if err != nil {
+		return err
+	}
+	blog, err := s.findSavableBlog(author, slug)
+	if err != nil {
+		return err
+	}
+	return s.readingListRepo.AddItemIfNotExists(&models.ReadingListItem{ReadingListID: readingList.ID, BlogID: blog.ID})
+}
+
+func (s *bookmarkServiceImpl) RemoveFromReadingList(userId uint, id uint, author string, slug string) error {
+	readingList, err := s.findOwnedReadingList(userId, id)
+	if err != nil {
+		return err
+	}
+	blog, err := s.blogRepo.FindByUsernameAndSlug(author, slug)
+	if err != nil {
+		return fmt.Errorf("blog by author '%s' and slug '%s' could not be found: %w", author, slug, ErrBlogNotFound)
+	}
+	return s.readingListRepo.RemoveItem(readingList.ID, blog.ID)
+}
+
+func (s *bookmarkServiceImpl) ReorderReadingList(userId uint, id uint, orderDto dto.BlogOrderRequestDto) (*dto.ReadingListDetailDto, error) {
+	readingList, err := s.findOwnedReadingList(userId, id)
+	if err != nil {
+		return nil, err
+	}
+	blogIds, err := s.resolveOrder(orderDto)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.readingListRepo.Reorder(readingList.ID, blogIds); err != nil {
+		return nil, err
+	}
+	return s.FindReadingList(userId, id)
+}
+
+// saveReadingList applies the requested name, keeping names unique per user
+func (s *bookmarkServiceImpl) saveReadingList(readingList models.ReadingList, requestDto dto.ReadingListRequestDto) (*dto.ReadingListDto, error) {
+	requestDto.Name = strings.TrimSpace(requestDto.Name)
+	if err := requestDto.Validate(); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	taken, err := s.readingListRepo.ExistsByUserIdAndName(readingList.UserID, requestDto.Name, readingList.ID)
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, ErrReadingListNameTaken
+	}
+
+	readingList.Name = requestDto.Name
+	if err := s.readingListRepo.Save(&readingList); err != nil {
+		return nil, err
+	}
+	counts, err := s.readingListRepo.CountItemsByReadingListIds([]uint{readingList.ID})
+	if err != nil {
+		return nil, err
+	}
+	readingListDto := toReadingListDto(readingList, counts[readingList.ID])
+	return &readingListDto, nil
+}
+
+// resolveOrder validates a reorder request and resolves its authors and slugs to blog ids
+func (s *bookmarkServiceImpl) resolveOrder(orderDto dto.BlogOrderRequestDto) ([]uint, error) {
+	if err := orderDto.Validate(); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+	blogIds := make([]uint, 0, len(orderDto.Blogs))
+	for _, ref := range orderDto.Blogs {
+		blog, err := s.blogRepo.FindByUsernameAndSlug(ref.Author, ref.Slug)
+		if err != nil {
+			return nil, fmt.Errorf("blog by author '%s' and slug '%s' could not be found: %w", ref.Author, ref.Slug, ErrBlogNotFound)
+		}
+		blogIds = append(blogIds, blog.ID)
+	}
+	return blogIds, nil
+}
+
+func (s *bookmarkServiceImpl) findOwnedReadingList(userId uint, id uint) (models.ReadingList, error) {
+	readingList, err := s.readingListRepo.FindByIdAndUserId(id, userId)
+	if err != nil {
+		return models.ReadingList{}, ErrReadingListNotFound
+	}
+	return readingList, nil
+}
+
+func (s *bookmarkServiceImpl) findSavableBlog(author string, slug string) (models.Blog, error) {
+	blog, err := s.blogRepo.FindByUsernameAndSlug(author, slug)
+	if err != nil || !blog.Published || blog.IsDeleted {
+		return models.Blog{}, fmt.Errorf("blog by author '%s' and slug '%s' could not be found: %w", author, slug, ErrBlogNotFound)
+	}
+	return blog, nil
+}
+
+func toReadingListDto(readingList models.ReadingList, itemCount int64) dto.ReadingListDto {
+	return dto.ReadingListDto{
+		ID:        readingList.ID,
+		Name:      readingList.Name,
+		ItemCount: itemCount,
+		CreatedAt: mapper.GetTimeAgo(readingList.CreatedAt),
+		UpdatedAt: mapper.GetTimeAgo(readingList.UpdatedAt),
+	}
+}
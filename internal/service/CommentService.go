@@ -0,0 +1,376 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"yp-blog-api/internal/dto"
+	"yp-blog-api/internal/mapping"
+	"yp-blog-api/internal/models"
+	"yp-blog-api/internal/pubsub"
+	"yp-blog-api/internal/repository"
+)
+
+// commentEditWindow is how long after posting a comment can still be edited
+const commentEditWindow = 15 * time.Minute
+
+var (
+	ErrCommentNotFound          = errors.New("comment not found")
+	ErrParentCommentNotFound    = errors.New("parent comment not found on this blog")
+	ErrGuestNameRequired        = errors.New("guestName is required to comment without signing in")
+	ErrCommentForbidden         = errors.New("invalid comment edit token")
+	ErrCommentEditWindowExpired = errors.New("comment can no longer be edited")
+	ErrUserNotFound             = errors.New("user not found")
+)
+
+type CommentService interface {
+	FindThreadByAuthorAndSlug(author string, slug string) ([]dto.CommentDto, error)
+	// CreateComment posts a comment as commenter, or as a guest when commenter is nil
+	CreateComment(author string, slug string, commenter *models.User, ipAddress string, commentDto dto.CommentCreateRequestDto) (*dto.CommentDto, error)
+	UpdateComment(author string, slug string, id uint, editToken string, commentDto dto.CommentUpdateRequestDto) (*dto.CommentDto, error)
+	DeleteComment(author string, slug string, id uint, editToken string) error
+
+	FindModerationQueue(status string) ([]dto.CommentModerationDto, error)
+	ModerateComment(id uint, moderationDto dto.CommentModerationRequestDto) error
+	GetModerationRule(username string) (*dto.CommentModerationRuleDto, error)
+	SaveModerationRule(username string, ruleDto dto.CommentModerationRuleDto) (*dto.CommentModerationRuleDto, error)
+}
+
+type commentServiceImpl struct {
+	commentRepo   repositories.CommentRepository
+	blogRepo      repositories.BlogRepository
+	userRepo      repositories.UserRepository
+	commentMapper mapper.CommentMapper
+	spamScorer    *spamScorer
+	notifier      NotificationService
+	bus           *pubsub.Bus
+}
+
+// NewCommentService creates a CommentService; spamBlocklist extends the built-in list of spam phrases
+func NewCommentService(commentRepo repositories.CommentRepository, blogRepo repositories.BlogRepository, userRepo repositories.UserRepository, commentMapper mapper.CommentMapper, spamBlocklist []string, notifier NotificationService, bus *pubsub.Bus) CommentService {
+	return &commentServiceImpl{
+		commentRepo:   commentRepo,
+		blogRepo:      blogRepo,
+		userRepo:      userRepo,
+		commentMapper: commentMapper,
+		spamScorer:    newSpamScorer(commentRepo, spamBlocklist),
+		notifier:      notifier,
+		bus:           bus,
+	}
+}
+
+func (s *commentServiceImpl) FindThreadByAuthorAndSlug(author string, slug string) ([]dto.CommentDto, error) {
+	blog, err := s.findCommentableBlog(author, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.commentRepo.FindAllByBlogId(blog.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only approved comments are shown; deleted ones are kept as thread placeholders
+	var visible []models.Comment
+	for _, comment := range comments {
+		if comment.Status == models.CommentStatusApproved {
+			visible = append(visible, comment)
+		}
+	}
+	return s.commentMapper.CommentsToThread(visible), nil
+}
+
+func (s *commentServiceImpl) CreateComment(author string, slug string, commenter *models.User, ipAddress string, commentDto dto.CommentCreateRequestDto) (*dto.CommentDto, error) {
+	if err := commentDto.Validate(); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+	if commenter == nil && strings.TrimSpace(commentDto.GuestName) == "" {
+		return nil, ErrGuestNameRequired
+	}
+
+	blog, err := s.findCommentableBlog(author, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replies must stay within the thread of the same blog, under a comment readers can see
+	if commentDto.ParentID != nil {
+		parent, err := s.commentRepo.FindById(*commentDto.ParentID)
+		if err != nil || parent.BlogID != blog.ID || parent.Status != models.CommentStatusApproved || parent.IsDeleted {
+			return nil, ErrParentCommentNotFound
+		}
+	}
+
+	editToken, err := generateEditToken()
+	if err != nil {
+		return nil, err
+	}
+
+	comment := s.commentMapper.CreateDtoToComment(commentDto)
+	comment.BlogID = blog.ID
+	comment.GuestName = strings.TrimSpace(comment.GuestName)
+	if commenter != nil {
+		// Signed-in readers cannot pass themselves off as someone else
+		comment.AuthorID = &commenter.ID
+		comment.Author = commenter
+		comment.GuestName = ""
+	}
+	comment.EditTokenHash = hashEditToken(editToken)
+	comment.IPAddress = ipAddress
+	comment.ContentHash = commentContentHash(comment.Body)
+
+	// Suspicious comments go to the moderation queue, the rest follow the author's rule
+	score, reasons, err := s.spamScorer.Score(comment)
+	if err != nil {
+		return nil, fmt.Errorf("error scoring comment: %v", err)
+	}
+	comment.SpamScore = score
+	comment.SpamReasons = strings.Join(reasons, "; ")
+	comment.Status, err = s.initialStatus(blog, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.commentRepo.Create(&comment); err != nil {
+		return nil, fmt.Errorf("error saving comment: %v", err)
+	}
+	if comment.Status == models.CommentStatusApproved {
+		s.notifier.Publish(CommentPostedEvent{Blog: blog, Comment: comment})
+		s.publishNewComment(blog, comment)
+	}
+
+	commentResponse := s.commentMapper.CommentToDto(comment)
+	commentResponse.EditToken = editToken
+	return &commentResponse, nil
+}
+
+func (s *commentServiceImpl) UpdateComment(author string, slug string, id uint, editToken string, commentDto dto.CommentUpdateRequestDto) (*dto.CommentDto, error) {
+	if err := commentDto.Validate(); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	comment, err := s.findOwnedComment(author, slug, id, editToken)
+	if err != nil {
+		return nil, err
+	}
+	if time.Since(comment.CreatedAt) > commentEditWindow {
+		return nil, ErrCommentEditWindowExpired
+	}
+
+	// Re-score edited text so an approved comment cannot be turned into spam afterwards
+	if comment.Body != commentDto.Body {
+		comment.Body = commentDto.Body
+		comment.ContentHash = commentContentHash(comment.Body)
+		score, reasons, err := s.spamScorer.Score(*comment)
+		if err != nil {
+			return nil, fmt.Errorf("error scoring comment: %v", err)
+		}
+		comment.SpamScore = score
+		comment.SpamReasons = strings.Join(reasons, "; ")
+		if score >= spamPendingScore && comment.Status == models.CommentStatusApproved {
+			comment.Status = models.CommentStatusPending
+		}
+	}
+
+	now := time.Now()
+	comment.EditedAt = &now
+	if err := s.commentRepo.Update(comment); err != nil {
+		return nil, err
+	}
+
+	commentResponse := s.commentMapper.CommentToDto(*comment)
+	return &commentResponse, nil
+}
+
+// DeleteComment soft deletes a comment so replies to it keep their place in the thread
+func (s *commentServiceImpl) DeleteComment(author string, slug string, id uint, editToken string) error {
+	comment, err := s.findOwnedComment(author, slug, id, editToken)
+	if err != nil {
+		return err
+	}
+
+	comment.IsDeleted = true
+	return s.commentRepo.Update(comment)
+}
+
+// initialStatus decides the moderation status of a new comment from its spam score
+// and the moderation rule of the blog's author
+func (s *commentServiceImpl) initialStatus(blog models.Blog, comment models.Comment) (string, error) {
+	switch {
+	case comment.SpamScore >= spamRejectScore:
+		return models.CommentStatusSpam, nil
+	case comment.SpamScore >= spamPendingScore:
+		return models.CommentStatusPending, nil
+	}
+
+	rule, err := s.commentRepo.FindModerationRuleByAuthorId(blog.AuthorID)
+	if err != nil {
+		return "", err
+	}
+	mode := models.ModerationModeAutoApprove
+	if rule != nil {
+		mode = rule.Mode
+	}
+
+	switch mode {
+	case models.ModerationModeManual:
+		return models.CommentStatusPending, nil
+	case models.ModerationModeAutoApproveTrusted:
+		// Anyone can type a guest's name, so only signed-in readers can earn trust
+		if comment.AuthorID == nil {
+			return models.CommentStatusPending, nil
+		}
+		trusted, err := s.commentRepo.ExistsApprovedByUserIdForAuthor(*comment.AuthorID, blog.AuthorID)
+		if err != nil {
+			return "", err
+		}
+		if !trusted {
+			return models.CommentStatusPending, nil
+		}
+	}
+	return models.CommentStatusApproved, nil
+}
+
+// FindModerationQueue lists comments in a moderation status, pending ones by default
+func (s *commentServiceImpl) FindModerationQueue(status string) ([]dto.CommentModerationDto, error) {
+	if status == "" {
+		status = models.CommentStatusPending
+	}
+	moderationDto := dto.CommentModerationRequestDto{Status: status}
+	if err := moderationDto.Validate(); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	comments, err := s.commentRepo.FindAllByStatus(status)
+	if err != nil {
+		return nil, err
+	}
+	return s.commentMapper.CommentsToModerationDtos(comments), nil
+}
+
+// ModerateComment records a moderator's decision on a comment
+func (s *commentServiceImpl) ModerateComment(id uint, moderationDto dto.CommentModerationRequestDto) error {
+	if err := moderationDto.Validate(); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+
+	comment, err := s.commentRepo.FindById(id)
+	if err != nil || comment.IsDeleted {
+		return ErrCommentNotFound
+	}
+
+	approved := comment.Status != models.CommentStatusApproved && moderationDto.Status == models.CommentStatusApproved
+	comment.Status = moderationDto.Status
+	if err := s.commentRepo.Update(comment); err != nil {
+		return err
+	}
+
+	// A held comment that is approved goes live only now
+	if approved {
+		blog, err := s.blogRepo.FindByIdWithAssociations(comment.BlogID)
+		if err != nil {
+			return err
+		}
+		s.notifier.Publish(CommentPostedEvent{Blog: blog, Comment: *comment})
+		s.notifier.Publish(CommentApprovedEvent{Blog: blog, Comment: *comment})
+		s.publishNewComment(blog, *comment)
+	}
+	return nil
+}
+
+// publishNewComment streams a comment that went live to the readers of its blog; the
+// blog must come with its author
+func (s *commentServiceImpl) publishNewComment(blog models.Blog, comment models.Comment) {
+	s.bus.Publish(pubsub.Event{
+		Type:   StreamEventNewComment,
+		Topics: []string{pubsub.BlogTopic(blog.Author.UserName, blog.Slug)},
+		Data:   s.commentMapper.CommentToDto(comment),
+	})
+}
+
+func (s *commentServiceImpl) GetModerationRule(username string) (*dto.CommentModerationRuleDto, error) {
+	user, err := s.userRepo.FindByUserName(username)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	rule, err := s.commentRepo.FindModerationRuleByAuthorId(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	ruleDto := dto.CommentModerationRuleDto{UserName: user.UserName, Mode: models.ModerationModeAutoApprove}
+	if rule != nil {
+		ruleDto.Mode = rule.Mode
+	}
+	return &ruleDto, nil
+}
+
+func (s *commentServiceImpl) SaveModerationRule(username string, ruleDto dto.CommentModerationRuleDto) (*dto.CommentModerationRuleDto, error) {
+	if err := ruleDto.Validate(); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	user, err := s.userRepo.FindByUserName(username)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	rule, err := s.commentRepo.FindModerationRuleByAuthorId(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		rule = &models.CommentModerationRule{AuthorID: user.ID}
+	}
+	rule.Mode = ruleDto.Mode
+	if err := s.commentRepo.SaveModerationRule(rule); err != nil {
+		return nil, err
+	}
+	return &dto.CommentModerationRuleDto{UserName: user.UserName, Mode: rule.Mode}, nil
+}
+
+// findCommentableBlog resolves a published, non-deleted blog by author and slug
+func (s *commentServiceImpl) findCommentableBlog(author string, slug string) (models.Blog, error) {
+	blog, err := s.blogRepo.FindByUsernameAndSlug(author, slug)
+	if err != nil || !blog.Published || blog.IsDeleted {
+		return models.Blog{}, fmt.Errorf("blog by author '%s' and slug '%s' could not be found: %w", author, slug, ErrBlogNotFound)
+	}
+	return blog, nil
+}
+
+// findOwnedComment loads a live comment of the blog and checks the caller's edit token
+func (s *commentServiceImpl) findOwnedComment(author string, slug string, id uint, editToken string) (*models.Comment, error) {
+	blog, err := s.findCommentableBlog(author, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.commentRepo.FindById(id)
+	if err != nil || comment.BlogID != blog.ID || comment.IsDeleted {
+		return nil, ErrCommentNotFound
+	}
+	if editToken == "" || subtle.ConstantTimeCompare([]byte(hashEditToken(editToken)), []byte(comment.EditTokenHash)) != 1 {
+		return nil, ErrCommentForbidden
+	}
+	return comment, nil
+}
+
+// generateEditToken returns a random token handed to the commenter once
+func generateEditToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate edit token: %v", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// hashEditToken hashes an edit token so only its digest is stored
+func hashEditToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
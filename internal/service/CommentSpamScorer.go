@@ -0,0 +1,120 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"yp-blog-api/internal/models"
+	"yp-blog-api/internal/repository"
+)
+
+const (
+	// Comments scoring at least spamPendingScore wait in the moderation queue and
+	// those scoring at least spamRejectScore are marked as spam outright
+	spamPendingScore = 0.5
+	spamRejectScore  = 1.0
+
+	spamLinkLimit           = 2
+	spamLinkDensityLimit    = 0.1
+	spamRepeatWindow        = 24 * time.Hour
+	spamIpRateWindow        = 10 * time.Minute
+	spamIpRateLimit         = 5
+	spamBlockedWordScore    = 0.4
+	spamLinkScore           = 0.3
+	spamLinkDensityScore    = 0.3
+	spamRepeatContentScore  = 0.6
+	spamIpRateExceededScore = 0.6
+)
+
+// defaultSpamBlocklist holds English and Khmer phrases common in comment spam
+var defaultSpamBlocklist = []string{
+	"viagra", "cialis", "casino", "porn", "xxx", "payday loan", "free money",
+	"crypto giveaway", "work from home", "click here", "buy followers", "bitcoin doubler",
+	"កាស៊ីណូ",         // casino
+	"ល្បែងស៊ីសង",      // gambling
+	"ភ្នាល់បាល់",      // football betting
+	"ឆ្នោតអនឡាញ",      // online lottery
+	"ប្រាក់កម្ចីរហ័ស", // quick loan
+}
+
+var linkPattern = regexp.MustCompile(`(?i)(https?://|www\.)\S+`)
+
+// spamScorer scores comments with offline heuristics: link density, blocklisted
+// words, repeated content and the posting rate of the sender's IP address
+type spamScorer struct {
+	commentRepo repositories.CommentRepository
+	blocklist   []string
+}
+
+// newSpamScorer creates a spamScorer using the default blocklist extended with extraWords
+func newSpamScorer(commentRepo repositories.CommentRepository, extraWords []string) *spamScorer {
+	var blocklist []string
+	for _, word := range append(append([]string{}, defaultSpamBlocklist...), extraWords...) {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			blocklist = append(blocklist, word)
+		}
+	}
+	return &spamScorer{commentRepo: commentRepo, blocklist: blocklist}
+}
+
+// Score returns the spam score of a comment and the reasons that contributed to it
+func (s *spamScorer) Score(comment models.Comment) (float64, []string, error) {
+	score := 0.0
+	var reasons []string
+
+	body := strings.ToLower(comment.Body)
+	links := len(linkPattern.FindAllString(body, -1))
+	words := len(strings.FieldsFunc(body, unicode.IsSpace))
+	if links > spamLinkLimit {
+		score += spamLinkScore
+		reasons = append(reasons, fmt.Sprintf("contains %d links", links))
+	}
+	if links > 0 && words > 0 && float64(links)/float64(words) > spamLinkDensityLimit {
+		score += spamLinkDensityScore
+		reasons = append(reasons, "high link density")
+	}
+
+	for _, word := range s.blocklist {
+		if strings.Contains(body, word) || strings.Contains(strings.ToLower(comment.GuestName), word) {
+			score += spamBlockedWordScore
+			reasons = append(reasons, fmt.Sprintf("contains blocked phrase %q", word))
+		}
+	}
+
+	now := time.Now()
+	repeats, err := s.commentRepo.CountByContentHashSince(comment.ContentHash, now.Add(-spamRepeatWindow))
+	if err != nil {
+		return 0, nil, err
+	}
+	if repeats > 0 {
+		score += spamRepeatContentScore
+		reasons = append(reasons, fmt.Sprintf("same content already posted %d time(s) in the last day", repeats))
+	}
+
+	if comment.IPAddress != "" {
+		recent, err := s.commentRepo.CountByIpAddressSince(comment.IPAddress, now.Add(-spamIpRateWindow))
+		if err != nil {
+			return 0, nil, err
+		}
+		if recent >= spamIpRateLimit {
+			score += spamIpRateExceededScore
+			reasons = append(reasons, fmt.Sprintf("%d comments from the same IP in the last %s", recent, spamIpRateWindow))
+		}
+	}
+
+	return score, reasons, nil
+}
+
+// commentContentHash hashes a comment body ignoring case, punctuation and spacing,
+// so lightly edited copies of the same message are still recognised
+func commentContentHash(body string) string {
+	normalised := strings.Join(strings.FieldsFunc(strings.ToLower(body), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+	}), " ")
+	sum := sha256.Sum256([]byte(normalised))
+	return hex.EncodeToString(sum[:])
+}
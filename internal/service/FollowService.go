@@ -0,0 +1,204 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"yp-blog-api/internal/dto"
+	"yp-blog-api/internal/models"
+	"yp-blog-api/internal/repository"
+)
+
+const (
+	feedDefaultLimit = 20
+	feedMaxLimit     = 50
+)
+
+var (
+	ErrCannotFollowSelf  = errors.New("you cannot follow yourself")
+	ErrTagNotFound       = errors.New("tag not found")
+	ErrInvalidFeedCursor = errors.New("invalid feed cursor")
+)
+
+// FollowService manages who follows which authors and tags, and builds the
+// personalized feed from them
+type FollowService interface {
+	FollowAuthor(userId uint, username string) error
+	UnfollowAuthor(userId uint, username string) error
+	FollowTag(userId uint, tagId uint) error
+	UnfollowTag(userId uint, tagId uint) error
+	FindFollowing(userId uint) (*dto.FollowingDto, error)
+	FindAuthorProfile(username string, viewerID *uint) (*dto.AuthorCardDetailDto, error)
+	FindFeed(userId uint, cursor string, limit int) (*dto.FeedDto, error)
+}
+
+type followServiceImpl struct {
+	followRepo  repositories.FollowRepository
+	userRepo    repositories.UserRepository
+	tagRepo     repositories.TagRepository
+	blogRepo    repositories.BlogRepository
+	blogService BlogService
+}
+
+// NewFollowService creates a FollowService; blogService renders feed blogs as cards
+func NewFollowService(followRepo repositories.FollowRepository, userRepo repositories.UserRepository, tagRepo repositories.TagRepository, blogRepo repositories.BlogRepository, blogService BlogService) FollowService {
+	return &followServiceImpl{
+		followRepo:  followRepo,
+		userRepo:    userRepo,
+		tagRepo:     tagRepo,
+		blogRepo:    blogRepo,
+		blogService: blogService,
+	}
+}
+
+// FollowAuthor follows an author; following them again has no further effect
+func (s *followServiceImpl) FollowAuthor(userId uint, username string) error {
+	author, err := s.userRepo.FindByUserName(username)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	if author.ID == userId {
+		return ErrCannotFollowSelf
+	}
+	return s.followRepo.CreateIfNotExists(&models.Follow{FollowerID: userId, FolloweeID: author.ID})
+}
+
+// UnfollowAuthor stops following an author; unfollowing an author who is not followed has no effect
+func (s *followServiceImpl) UnfollowAuthor(userId uint, username string) error {
+	author, err := s.userRepo.FindByUserName(username)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	return s.followRepo.DeleteByFollowerIdAndFolloweeId(userId, author.ID)
+}
+
+// FollowTag follows a tag; following it again has no further effect
+func (s *followServiceImpl) FollowTag(userId uint, tagId uint) error {
+	tag, err := s.tagRepo.FindById(int(tagId))
+	if err != nil || tag.IsDeleted {
+		return ErrTagNotFound
+	}
+	return s.followRepo.CreateTagFollowIfNotExists(&models.TagFollow{UserID: userId, TagID: tag.ID})
+}
+
+func (s *followServiceImpl) UnfollowTag(userId uint, tagId uint) error {
+	return s.followRepo.DeleteTagFollowByUserIdAndTagId(userId, tagId)
+}
+
+func (s *followServiceImpl) FindFollowing(userId uint) (*dto.FollowingDto, error) {
+	authors, err := s.followRepo.FindFolloweesByFollowerId(userId)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := s.followRepo.FindFollowedTagsByUserId(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	following := &dto.FollowingDto{
+		Authors: make([]dto.AuthorCardDto, len(authors)),
+		Tags:    make([]dto.TagDto, len(tags)),
+	}
+	for i, author := range authors {
+		following.Authors[i] = dto.AuthorCardDto{ProfileImage: author.ProfileImage, UserName: author.UserName}
+	}
+	for i, tag := range tags {
+		following.Tags[i] = dto.TagDto{ID: int64(tag.ID), Title: tag.Title}
+	}
+	return following, nil
+}
+
+func (s *followServiceImpl) FindAuthorProfile(username string, viewerID *uint) (*dto.AuthorCardDetailDto, error) {
+	author, err := s.userRepo.FindByUserName(username)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	profile := &dto.AuthorCardDetailDto{
+		ProfileImage: author.ProfileImage,
+		UserName:     author.UserName,
+		Bio:          author.Bio,
+	}
+	if err := fillFollowStats(s.followRepo, profile, author.ID, viewerID); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// FindFeed returns a page of blogs from followed authors and tags, newest first.
+// cursor is the NextCursor of the previous page, or empty for the first page.
+func (s *followServiceImpl) FindFeed(userId uint, cursor string, limit int) (*dto.FeedDto, error) {
+	if limit <= 0 {
+		limit = feedDefaultLimit
+	}
+	if limit > feedMaxLimit {
+		limit = feedMaxLimit
+	}
+	before, err := decodeFeedCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch one extra blog to learn whether another page follows
+	blogs, err := s.blogRepo.FindFeedPage(userId, before, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	feed := &dto.FeedDto{}
+	if len(blogs) > limit {
+		blogs = blogs[:limit]
+		last := blogs[len(blogs)-1]
+		feed.NextCursor = encodeFeedCursor(repositories.FeedCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+	feed.Blogs = s.blogService.ToBlogCards(blogs, &userId)
+	return feed, nil
+}
+
+// fillFollowStats sets the follower counts of an author profile and whether the viewer follows the author
+func fillFollowStats(followRepo repositories.FollowRepository, profile *dto.AuthorCardDetailDto, authorId uint, viewerID *uint) error {
+	var err error
+	if profile.FollowerCount, err = followRepo.CountByFolloweeId(authorId); err != nil {
+		return err
+	}
+	if profile.FollowingCount, err = followRepo.CountByFollowerId(authorId); err != nil {
+		return err
+	}
+	if viewerID != nil {
+		if profile.Following, err = followRepo.ExistsByFollowerIdAndFolloweeId(*viewerID, authorId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeFeedCursor turns the position of the last blog of a page into an opaque cursor
+func encodeFeedCursor(cursor repositories.FeedCursor) string {
+	raw := fmt.Sprintf("%d:%d", cursor.CreatedAt.UnixNano(), cursor.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeFeedCursor(cursor string) (*repositories.FeedCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidFeedCursor
+	}
+	nanos, id, found := strings.Cut(string(raw), ":")
+	if !found {
+		return nil, ErrInvalidFeedCursor
+	}
+	createdAt, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidFeedCursor
+	}
+	blogId, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return nil, ErrInvalidFeedCursor
+	}
+	// Timestamps are stored in local time, so compare against local time as well
+	return &repositories.FeedCursor{CreatedAt: time.Unix(0, createdAt), ID: uint(blogId)}, nil
+}
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+	"yp-blog-api/internal/dto"
+	"yp-blog-api/internal/mapping"
+	"yp-blog-api/internal/models"
+	"yp-blog-api/internal/repository"
+	"yp-blog-api/internal/utils"
+)
+
+const (
+	// defaultJobMaxAttempts is how many times a job runs before it is dead, unless the job says otherwise
+	defaultJobMaxAttempts = 5
+	// jobRetryBaseDelay is the wait after the first failed attempt; it doubles with every attempt
+	jobRetryBaseDelay = 10 * time.Second
+	// jobRetryMaxDelay caps the wait between two attempts
+	jobRetryMaxDelay = time.Hour
+
+	defaultJobsLimit = 50
+	maxJobsLimit     = 200
+)
+
+var (
+	ErrJobNotFound       = errors.New("job not found")
+	ErrJobNotDead        = errors.New("only dead jobs can be retried")
+	ErrUnknownJobStatus  = errors.New("unknown job status")
+	errNoJobHandler      = errors.New("no handler is registered for the job type")
+	errJobHandlerPanic   = errors.New("job handler panicked")
+	errJobPayloadInvalid = errors.New("job payload could not be decoded")
+)
+
+// JobHandler runs a job with its raw JSON payload. The context is cancelled when the
+// job's visibility timeout expires, after which another worker may pick the job up.
+type JobHandler func(ctx context.Context, payload []byte) error
+
+// JobOptions tune how a job is enqueued. The zero value runs the job as soon as
+// possible with the default number of attempts.
+type JobOptions struct {
+	// UniqueKey skips the job while another job with the same key is queued or running
+	UniqueKey string
+	// RunAt delays the job until the given time
+	RunAt time.Time
+	// MaxAttempts overrides how many times the job runs before it is dead
+	MaxAttempts int
+}
+
+// JobQueue is a durable queue of background jobs stored in the database
+type JobQueue interface {
+	// Register sets the handler of a job type; see HandleJob for typed payloads
+	Register(jobType string, handler JobHandler)
+	// Enqueue stores a job with a JSON encoded payload. When the job is deduplicated by
+	// its unique key, the job that is already queued or running is returned.
+	Enqueue(jobType string, payload interface{}, options JobOptions) (*models.Job, error)
+	// RunNext claims and runs the next ready job, reporting whether there was one
+	RunNext() (bool, error)
+	// Run starts the workers, which poll for ready jobs every pollInterval when idle
+	Run(workers int, pollInterval time.Duration)
+
+	FindJobs(status string, jobType string, limit int) ([]dto.JobDto, error)
+	FindJob(id uint) (*dto.JobDto, error)
+	RetryJob(id uint) (*dto.JobDto, error)
+}
+
+type jobQueueImpl struct {
+	jobRepo           repositories.JobRepository
+	visibilityTimeout time.Duration
+	mu                sync.RWMutex
+	handlers          map[string]JobHandler
+}
+
+// NewJobQueue creates a JobQueue. Jobs still running after visibilityTimeout are
+// considered abandoned and run again.
+func NewJobQueue(jobRepo repositories.JobRepository, visibilityTimeout time.Duration) JobQueue {
+	return &jobQueueImpl{
+		jobRepo:           jobRepo,
+		visibilityTimeout: visibilityTimeout,
+		handlers:          make(map[string]JobHandler),
+	}
+}
+
+// HandleJob registers a handler that receives the job payload decoded into T
+func HandleJob[T any](queue JobQueue, jobType string, handler func(ctx context.Context, payload T) error) {
+	queue.Register(jobType, func(ctx context.Context, raw []byte) error {
+		var payload T
+		if err := json.Unmarshal(raw, &payload); err != nil {
+			return fmt.Errorf("%w: %v", errJobPayloadInvalid, err)
+		}
+		return handler(ctx, payload)
+	})
+}
+
+func (q *jobQueueImpl) Register(jobType string, handler JobHandler) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers[jobType] = handler
+}
+
+func (q *jobQueueImpl) Enqueue(jobType string, payload interface{}, options JobOptions) (*models.Job, error) {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding job payload: %v", err)
+	}
+	job := models.Job{
+		Type:        jobType,
+		Payload:     string(encoded),
+		UniqueKey:   options.UniqueKey,
+		Status:      models.JobStatusQueued,
+		MaxAttempts: options.MaxAttempts,
+		RunAt:       options.RunAt,
+	}
+	if job.MaxAttempts <= 0 {
+		job.MaxAttempts = defaultJobMaxAttempts
+	}
+	if job.RunAt.IsZero() {
+		job.RunAt = time.Now()
+	}
+	if _, err := q.jobRepo.Enqueue(&job); err != nil {
+		return nil, fmt.Errorf("error saving job: %v", err)
+	}
+	return &job, nil
+}
+
+func (q *jobQueueImpl) Run(workers int, pollInterval time.Duration) {
+	for i := 0; i < workers; i++ {
+		go func() {
+			for {
+				ran, err := q.RunNext()
+				if err != nil {
+					log.Printf("Error occurred while running jobs: %v", err)
+				}
+				if !ran || err != nil {
+					time.Sleep(pollInterval)
+				}
+			}
+		}()
+	}
+}
+
+func (q *jobQueueImpl) RunNext() (bool, error) {
+	now := time.Now()
+	job, err := q.jobRepo.Claim(now, now.Add(q.visibilityTimeout))
+	if err != nil || job == nil {
+		return false, err
+	}
+
+	runErr := q.run(job)
+	finished := time.Now()
+	job.LockedUntil = nil
+	switch {
+	case runErr == nil:
+		job.Status = models.JobStatusSucceeded
+		job.LastError = ""
+		job.FinishedAt = &finished
+	case job.Attempts >= job.MaxAttempts:
+		job.Status = models.JobStatusDead
+		job.LastError = utils.TruncateRunes(runErr.Error(), 1000)
+		job.FinishedAt = &finished
+		log.Printf("Job %d (%s) is dead after %d attempts: %v", job.ID, job.Type, job.Attempts, runErr)
+	default:
+		job.Status = models.JobStatusQueued
+		job.LastError = utils.TruncateRunes(runErr.Error(), 1000)
+		job.RunAt = finished.Add(utils.ExponentialBackoff(jobRetryBaseDelay, jobRetryMaxDelay, job.Attempts))
+	}
+	completed, err := q.jobRepo.Complete(job, job.Attempts)
+	if err != nil {
+		return true, err
+	}
+	if !completed {
+		log.Printf("Job %d (%s) outlived its lock; the outcome of attempt %d is dropped", job.ID, job.Type, job.Attempts)
+	}
+	return true, nil
+}
+
+// run calls the job's handler, turning a panic into an error so the job is retried
+func (q *jobQueueImpl) run(job *models.Job) (err error) {
+	q.mu.RLock()
+	handler, ok := q.handlers[job.Type]
+	q.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", errNoJobHandler, job.Type)
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%w: %v", errJobHandlerPanic, recovered)
+		}
+	}()
+	ctx, cancel := context.WithDeadline(context.Background(), *job.LockedUntil)
+	defer cancel()
+	return handler(ctx, []byte(job.Payload))
+}
+
+// FindJobs lists jobs, newest first, optionally in one status and of one type
+func (q *jobQueueImpl) FindJobs(status string, jobType string, limit int) ([]dto.JobDto, error) {
+	if status != "" && status != models.JobStatusQueued && status != models.JobStatusRunning &&
+		status != models.JobStatusSucceeded && status != models.JobStatusDead {
+		return nil, ErrUnknownJobStatus
+	}
+	if limit <= 0 {
+		limit = defaultJobsLimit
+	} else if limit > maxJobsLimit {
+		limit = maxJobsLimit
+	}
+
+	jobs, err := q.jobRepo.FindAll(status, jobType, limit)
+	if err != nil {
+		return nil, err
+	}
+	jobDtos := make([]dto.JobDto, len(jobs))
+	for i, job := range jobs {
+		jobDtos[i] = toJobDto(job)
+	}
+	return jobDtos, nil
+}
+
+func (q *jobQueueImpl) FindJob(id uint) (*dto.JobDto, error) {
+	job, err := q.jobRepo.FindById(id)
+	if err != nil {
+		return nil, ErrJobNotFound
+	}
+	jobDto := toJobDto(*job)
+	return &jobDto, nil
+}
+
+// RetryJob queues a dead job again with a fresh set of attempts
+func (q *jobQueueImpl) RetryJob(id uint) (*dto.JobDto, error) {
+	job, err := q.jobRepo.FindById(id)
+	if err != nil {
+		return nil, ErrJobNotFound
+	}
+	if job.Status != models.JobStatusDead {
+		return nil, ErrJobNotDead
+	}
+
+	job.Status = models.JobStatusQueued
+	job.Attempts = 0
+	job.RunAt = time.Now()
+	job.FinishedAt = nil
+	if err := q.jobRepo.Update(job); err != nil {
+		return nil, err
+	}
+	jobDto := toJobDto(*job)
+	return &jobDto, nil
+}
+
+func toJobDto(job models.Job) dto.JobDto {
+	jobDto := dto.JobDto{
+		ID:          job.ID,
+		Type:        job.Type,
+		Payload:     job.Payload,
+		UniqueKey:   job.UniqueKey,
+		Status:      job.Status,
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		RunAt:       job.RunAt.UTC().Format(time.RFC3339),
+		LastError:   job.LastError,
+		CreatedAt:   mapper.GetTimeAgo(job.CreatedAt),
+	}
+	if job.FinishedAt != nil {
+		jobDto.FinishedAt = mapper.GetTimeAgo(*job.FinishedAt)
+	}
+	return jobDto
+}
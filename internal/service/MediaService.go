@@ -0,0 +1,406 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"yp-blog-api/internal/dto"
+	"yp-blog-api/internal/imaging"
+	"yp-blog-api/internal/mapping"
+	"yp-blog-api/internal/models"
+	"yp-blog-api/internal/repository"
+	"yp-blog-api/internal/storage"
+	"yp-blog-api/internal/utils"
+)
+
+const (
+	// JobTypeMediaVariants generates the variants, dimensions and placeholder of an image
+	JobTypeMediaVariants = "media.variants"
+
+	defaultMediaLimit = 50
+	maxMediaLimit     = 200
+)
+
+// mediaVariantWidths are the widths images are scaled down to, for the srcset of cards
+// on phones, tablets and desktops
+var mediaVariantWidths = []int{320, 640, 1280}
+
+var (
+	ErrMediaNotFound         = errors.New("media not found")
+	ErrMediaTooLarge         = errors.New("file is too large")
+	ErrMediaTypeNotSupported = errors.New("only JPEG, PNG, GIF and WebP images can be uploaded")
+	ErrMediaInvalid          = errors.New("image is damaged, incomplete or too large")
+	ErrMediaInUse            = errors.New("media is used by a blog")
+)
+
+// mediaExtensions maps the accepted content types to the extension of their files
+var mediaExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// mediaKeyPattern finds storage keys in URLs, whatever address the storage is served
+// from: <owner id>/<32 hex digits>.<extension>, or <owner id>/<32 hex digits>-<width>w.<extension>
+// for variants
+var mediaKeyPattern = regexp.MustCompile(`\b\d+/[0-9a-f]{32}(?:-\d+w)?\.(?:jpg|png|gif|webp)\b`)
+
+// mediaVariantsJob is the payload of a JobTypeMediaVariants job
+type mediaVariantsJob struct {
+	MediaID uint `json:"mediaId"`
+}
+
+// MediaService stores the files users upload and keeps track of the blogs using them
+type MediaService interface {
+	// Upload stores a file of the owner's; its content type is sniffed from the content
+	// and its metadata stripped. Its variants are generated in the background.
+	Upload(ownerId uint, originalName string, file io.Reader) (*dto.MediaDto, error)
+	// FindMedia lists the owner's media library, newest first
+	FindMedia(ownerId uint, limit int) ([]dto.MediaDto, error)
+	FindMediaById(ownerId uint, id uint) (*dto.MediaDto, error)
+	// DeleteMedia deletes a file of the owner's that no blog uses
+	DeleteMedia(ownerId uint, id uint) error
+	// SyncBlogReferences records which media a blog uses in its thumbnail and content
+	SyncBlogReferences(blog models.Blog) error
+	// FindThumbnailImages returns the variants of the uploaded thumbnails of the given
+	// blogs, keyed by blog id; blogs without an uploaded thumbnail are left out
+	FindThumbnailImages(blogIds []uint) (map[uint]*dto.ResponsiveImageDto, error)
+	// GenerateVariants scales an image down, recording its dimensions and placeholder
+	GenerateVariants(ctx context.Context, id uint) error
+	// ScheduleMissingVariants queues the generation of the variants not generated yet
+	ScheduleMissingVariants() error
+}
+
+type mediaServiceImpl struct {
+	mediaRepo    repositories.MediaRepository
+	storage      storage.Storage
+	jobs         JobQueue
+	maxSizeBytes int64
+}
+
+// NewMediaService creates a MediaService that accepts files of at most maxSizeBytes
+// and generates their variants with the job queue
+func NewMediaService(mediaRepo repositories.MediaRepository, storage storage.Storage, jobs JobQueue, maxSizeBytes int64) MediaService {
+	s := &mediaServiceImpl{
+		mediaRepo:    mediaRepo,
+		storage:      storage,
+		jobs:         jobs,
+		maxSizeBytes: maxSizeBytes,
+	}
+	HandleJob(jobs, JobTypeMediaVariants, func(ctx context.Context, payload mediaVariantsJob) error {
+		return s.GenerateVariants(ctx, payload.MediaID)
+	})
+	return s
+}
+
+func (s *mediaServiceImpl) Upload(ownerId uint, originalName string, file io.Reader) (*dto.MediaDto, error) {
+	// Read one byte past the limit to tell a file of exactly the limit from a larger one
+	content, err := io.ReadAll(io.LimitReader(file, s.maxSizeBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading upload: %v", err)
+	}
+	if int64(len(content)) > s.maxSizeBytes {
+		return nil, ErrMediaTooLarge
+	}
+	contentType := http.DetectContentType(content)
+	extension, ok := mediaExtensions[contentType]
+	if !ok {
+		return nil, ErrMediaTypeNotSupported
+	}
+	// Images too large to decode would exhaust the memory of the upload and of the variants job
+	if err := imaging.CheckSize(content); err != nil {
+		if errors.Is(err, imaging.ErrImageTooLarge) {
+			return nil, fmt.Errorf("%w: %v", ErrMediaInvalid, err)
+		}
+		return nil, ErrMediaInvalid
+	}
+	if content, err = imaging.StripMetadata(contentType, content); err != nil {
+		if errors.Is(err, imaging.ErrInvalidImage) {
+			return nil, ErrMediaInvalid
+		}
+		return nil, fmt.Errorf("error stripping image metadata: %v", err)
+	}
+
+	name, err := generateMediaName()
+	if err != nil {
+		return nil, err
+	}
+	media := models.Media{
+		OwnerID:      ownerId,
+		StorageKey:   fmt.Sprintf("%d/%s%s", ownerId, name, extension),
+		OriginalName: utils.TruncateRunes(originalName, 255),
+		ContentType:  contentType,
+		Size:         int64(len(content)),
+	}
+	if err := s.storage.Put(context.Background(), media.StorageKey, contentType, bytes.NewReader(content), media.Size); err != nil {
+		return nil, fmt.Errorf("error storing upload: %v", err)
+	}
+	if err := s.mediaRepo.Create(&media); err != nil {
+		if deleteErr := s.storage.Delete(context.Background(), media.StorageKey); deleteErr != nil {
+			log.Printf("Error occurred while removing an orphaned upload: %v", deleteErr)
+		}
+		return nil, fmt.Errorf("error saving media: %v", err)
+	}
+	// The upload is usable without its variants, and missing ones are scheduled again on startup
+	if err := s.scheduleVariants(media.ID); err != nil {
+		log.Printf("Error occurred while scheduling media variants: %v", err)
+	}
+	mediaDto := s.toMediaDto(media)
+	return &mediaDto, nil
+}
+
+func (s *mediaServiceImpl) FindMedia(ownerId uint, limit int) ([]dto.MediaDto, error) {
+	if limit <= 0 {
+		limit = defaultMediaLimit
+	} else if limit > maxMediaLimit {
+		limit = maxMediaLimit
+	}
+	media, err := s.mediaRepo.FindAllByOwner(ownerId, limit)
+	if err != nil {
+		return nil, err
+	}
+	mediaDtos := make([]dto.MediaDto, len(media))
+	for i := range media {
+		mediaDtos[i] = s.toMediaDto(media[i])
+	}
+	return mediaDtos, nil
+}
+
+func (s *mediaServiceImpl) FindMediaById(ownerId uint, id uint) (*dto.MediaDto, error) {
+	media, err := s.mediaRepo.FindByIdAndOwner(id, ownerId)
+	if err != nil {
+		return nil, ErrMediaNotFound
+	}
+	mediaDto := s.toMediaDto(*media)
+	return &mediaDto, nil
+}
+
+func (s *mediaServiceImpl) DeleteMedia(ownerId uint, id uint) error {
+	media, err := s.mediaRepo.FindByIdAndOwner(id, ownerId)
+	if err != nil {
+		return ErrMediaNotFound
+	}
+	if len(media.References) > 0 {
+		return ErrMediaInUse
+	}
+	if err := s.mediaRepo.Delete(media); err != nil {
+		return err
+	}
+	// The row is gone, so a file left behind is only wasted space
+	keys := []string{media.StorageKey}
+	for _, variant := range media.Variants {
+		keys = append(keys, variant.StorageKey)
+	}
+	for _, key := range keys {
+		if err := s.storage.Delete(context.Background(), key); err != nil {
+			log.Printf("Error occurred while deleting media file %s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+func (s *mediaServiceImpl) SyncBlogReferences(blog models.Blog) error {
+	var references []models.MediaReference
+	if !blog.IsDeleted {
+		thumbnailKeys := mediaKeyPattern.FindAllString(blog.Thumbnail, -1)
+		contentKeys := mediaKeyPattern.FindAllString(blog.BlogContent, -1)
+		media, err := s.mediaRepo.FindAllByKeys(append(thumbnailKeys, contentKeys...))
+		if err != nil {
+			return err
+		}
+		for _, file := range media {
+			if mediaKeysContain(thumbnailKeys, file) {
+				references = append(references, models.MediaReference{MediaID: file.ID, BlogID: blog.ID, Field: models.MediaReferenceThumbnail})
+			}
+			if mediaKeysContain(contentKeys, file) {
+				references = append(references, models.MediaReference{MediaID: file.ID, BlogID: blog.ID, Field: models.MediaReferenceContent})
+			}
+		}
+	}
+	return s.mediaRepo.ReplaceBlogReferences(blog.ID, references)
+}
+
+// mediaKeysContain reports whether keys has the key of the file or of one of its variants
+func mediaKeysContain(keys []string, media models.Media) bool {
+	if containsString(keys, media.StorageKey) {
+		return true
+	}
+	for _, variant := range media.Variants {
+		if containsString(keys, variant.StorageKey) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *mediaServiceImpl) FindThumbnailImages(blogIds []uint) (map[uint]*dto.ResponsiveImageDto, error) {
+	thumbnails, err := s.mediaRepo.FindThumbnailsByBlogIds(blogIds)
+	if err != nil {
+		return nil, err
+	}
+	images := make(map[uint]*dto.ResponsiveImageDto, len(thumbnails))
+	for blogId, media := range thumbnails {
+		responsiveImage := s.toResponsiveImage(media)
+		images[blogId] = &responsiveImage
+	}
+	return images, nil
+}
+
+func (s *mediaServiceImpl) GenerateVariants(ctx context.Context, id uint) error {
+	media, err := s.mediaRepo.FindById(id)
+	if err != nil {
+		// Deleted since the job was queued
+		return nil
+	}
+	object, err := s.storage.Get(ctx, media.StorageKey)
+	if err != nil {
+		return fmt.Errorf("error reading media file %s: %v", media.StorageKey, err)
+	}
+	data, err := io.ReadAll(object)
+	object.Close()
+	if err != nil {
+		return fmt.Errorf("error reading media file %s: %v", media.StorageKey, err)
+	}
+	img, err := imaging.Decode(data)
+	if err != nil {
+		// Corrupt images and images too large to decode are refused; such uploads are
+		// served as they are. Retrying would not help, so the file is marked processed
+		// without variants.
+		log.Printf("Error occurred while decoding media %d, serving it without variants: %v", media.ID, err)
+		return s.mediaRepo.SaveVariants(media, nil)
+	}
+
+	media.Width, media.Height = img.Bounds().Dx(), img.Bounds().Dy()
+	if media.Placeholder, err = imaging.Placeholder(img); err != nil {
+		return fmt.Errorf("error rendering media placeholder: %v", err)
+	}
+	var variants []models.MediaVariant
+	// Scaling a GIF down would drop its animation
+	if media.ContentType != "image/gif" {
+		base := strings.TrimSuffix(media.StorageKey, path.Ext(media.StorageKey))
+		for _, width := range mediaVariantWidths {
+			if width >= media.Width {
+				break
+			}
+			resized := imaging.Resize(img, width)
+			encoded, contentType, err := imaging.Encode(resized)
+			if err != nil {
+				return fmt.Errorf("error encoding media variant: %v", err)
+			}
+			variant := models.MediaVariant{
+				MediaID:     media.ID,
+				Width:       resized.Bounds().Dx(),
+				Height:      resized.Bounds().Dy(),
+				StorageKey:  base + "-" + strconv.Itoa(width) + "w" + mediaExtensions[contentType],
+				ContentType: contentType,
+				Size:        int64(len(encoded)),
+			}
+			if err := s.storage.Put(ctx, variant.StorageKey, contentType, bytes.NewReader(encoded), variant.Size); err != nil {
+				return fmt.Errorf("error storing media variant: %v", err)
+			}
+			variants = append(variants, variant)
+		}
+	}
+	return s.mediaRepo.SaveVariants(media, variants)
+}
+
+func (s *mediaServiceImpl) ScheduleMissingVariants() error {
+	ids, err := s.mediaRepo.FindAllUnprocessedIds()
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := s.scheduleVariants(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *mediaServiceImpl) scheduleVariants(id uint) error {
+	_, err := s.jobs.Enqueue(JobTypeMediaVariants, mediaVariantsJob{MediaID: id},
+		JobOptions{UniqueKey: fmt.Sprintf("%s:%d", JobTypeMediaVariants, id)})
+	return err
+}
+
+func (s *mediaServiceImpl) toMediaDto(media models.Media) dto.MediaDto {
+	mediaDto := dto.MediaDto{
+		ID:           media.ID,
+		URL:          s.storage.URL(media.StorageKey),
+		OriginalName: media.OriginalName,
+		ContentType:  media.ContentType,
+		Size:         media.Size,
+		CreatedAt:    mapper.GetTimeAgo(media.CreatedAt),
+		Image:        s.toResponsiveImage(media),
+		References:   []dto.MediaReferenceDto{},
+	}
+	for _, reference := range media.References {
+		mediaDto.References = append(mediaDto.References, dto.MediaReferenceDto{
+			BlogID:    reference.BlogID,
+			BlogTitle: reference.Blog.BlogTitle,
+			Author:    reference.Blog.Author.UserName,
+			Slug:      reference.Blog.Slug,
+			Field:     reference.Field,
+		})
+	}
+	return mediaDto
+}
+
+// toResponsiveImage lists the variants of a file, smallest first, followed by the file itself
+func (s *mediaServiceImpl) toResponsiveImage(media models.Media) dto.ResponsiveImageDto {
+	responsiveImage := dto.ResponsiveImageDto{
+		Src:         s.storage.URL(media.StorageKey),
+		Width:       media.Width,
+		Height:      media.Height,
+		Placeholder: media.Placeholder,
+		Sources:     []dto.ImageSourceDto{},
+	}
+	for _, variant := range media.Variants {
+		responsiveImage.Sources = append(responsiveImage.Sources, dto.ImageSourceDto{
+			URL:         s.storage.URL(variant.StorageKey),
+			Width:       variant.Width,
+			Height:      variant.Height,
+			ContentType: variant.ContentType,
+		})
+	}
+	sort.Slice(responsiveImage.Sources, func(i, j int) bool {
+		return responsiveImage.Sources[i].Width < responsiveImage.Sources[j].Width
+	})
+	// The width of the file is unknown until it is processed
+	if media.Width == 0 {
+		return responsiveImage
+	}
+	responsiveImage.Sources = append(responsiveImage.Sources, dto.ImageSourceDto{
+		URL:         responsiveImage.Src,
+		Width:       media.Width,
+		Height:      media.Height,
+		ContentType: media.ContentType,
+	})
+	candidates := make([]string, len(responsiveImage.Sources))
+	for i, source := range responsiveImage.Sources {
+		candidates[i] = source.URL + " " + strconv.Itoa(source.Width) + "w"
+	}
+	responsiveImage.Srcset = strings.Join(candidates, ", ")
+	return responsiveImage
+}
+
+// generateMediaName returns a random file name, so stored files cannot be guessed
+func generateMediaName() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate media name: %v", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
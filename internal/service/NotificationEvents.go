@@ -0,0 +1,153 @@
+package service
+
+import (
+	"fmt"
+	"net/url"
+	"yp-blog-api/internal/models"
+	"yp-blog-api/internal/utils"
+)
+
+// NotificationEvent is something that happened in the blog that users may be told about.
+// Each event knows its notification type and who should receive it.
+type NotificationEvent interface {
+	// Type is one of the models.NotificationType* constants
+	Type() string
+	notifications(s *notificationServiceImpl) ([]models.Notification, error)
+}
+
+// CommentPostedEvent is emitted when a comment goes live on a blog, either right
+// away or once a moderator approves it
+type CommentPostedEvent struct {
+	Blog    models.Blog
+	Comment models.Comment
+}
+
+func (e CommentPostedEvent) Type() string {
+	return models.NotificationTypeCommentPosted
+}
+
+func (e CommentPostedEvent) notifications(s *notificationServiceImpl) ([]models.Notification, error) {
+	// Authors are not told about their own comments
+	if e.Blog.AuthorID == 0 || (e.Comment.AuthorID != nil && *e.Comment.AuthorID == e.Blog.AuthorID) {
+		return nil, nil
+	}
+	link, err := s.blogLink(e.Blog)
+	if err != nil {
+		return nil, err
+	}
+	return []models.Notification{{
+		UserID:    e.Blog.AuthorID,
+		Title:     fmt.Sprintf("%s commented on %q", commenterName(e.Comment), e.Blog.BlogTitle),
+		Message:   utils.TruncateRunes(e.Comment.Body, notificationExcerptLength),
+		Link:      fmt.Sprintf("%s#comment-%d", link, e.Comment.ID),
+		BlogID:    &e.Blog.ID,
+		CommentID: &e.Comment.ID,
+	}}, nil
+}
+
+// CommentApprovedEvent is emitted when a moderator approves a held comment
+type CommentApprovedEvent struct {
+	Blog    models.Blog
+	Comment models.Comment
+}
+
+func (e CommentApprovedEvent) Type() string {
+	return models.NotificationTypeCommentApproved
+}
+
+func (e CommentApprovedEvent) notifications(s *notificationServiceImpl) ([]models.Notification, error) {
+	// Only signed-in commenters have an inbox
+	if e.Comment.AuthorID == nil {
+		return nil, nil
+	}
+	link, err := s.blogLink(e.Blog)
+	if err != nil {
+		return nil, err
+	}
+	return []models.Notification{{
+		UserID:    *e.Comment.AuthorID,
+		Title:     fmt.Sprintf("Your comment on %q was approved", e.Blog.BlogTitle),
+		Message:   utils.TruncateRunes(e.Comment.Body, notificationExcerptLength),
+		Link:      fmt.Sprintf("%s#comment-%d", link, e.Comment.ID),
+		BlogID:    &e.Blog.ID,
+		CommentID: &e.Comment.ID,
+	}}, nil
+}
+
+// ViewMilestoneEvent is emitted when the view count of a blog reaches a milestone
+type ViewMilestoneEvent struct {
+	Blog      models.Blog
+	Milestone int
+}
+
+func (e ViewMilestoneEvent) Type() string {
+	return models.NotificationTypeViewMilestone
+}
+
+func (e ViewMilestoneEvent) notifications(s *notificationServiceImpl) ([]models.Notification, error) {
+	if e.Blog.AuthorID == 0 {
+		return nil, nil
+	}
+	link, err := s.blogLink(e.Blog)
+	if err != nil {
+		return nil, err
+	}
+	return []models.Notification{{
+		UserID: e.Blog.AuthorID,
+		Title:  fmt.Sprintf("%q reached %d views", e.Blog.BlogTitle, e.Milestone),
+		Link:   link,
+		BlogID: &e.Blog.ID,
+	}}, nil
+}
+
+// NewPostEvent is emitted when an author publishes a blog
+type NewPostEvent struct {
+	Blog models.Blog
+}
+
+func (e NewPostEvent) Type() string {
+	return models.NotificationTypeNewPost
+}
+
+func (e NewPostEvent) notifications(s *notificationServiceImpl) ([]models.Notification, error) {
+	if e.Blog.AuthorID == 0 || !e.Blog.Published {
+		return nil, nil
+	}
+	followerIds, err := s.followRepo.FindFollowerIdsByFolloweeId(e.Blog.AuthorID)
+	if err != nil || len(followerIds) == 0 {
+		return nil, err
+	}
+	author, err := s.userRepo.FindById(e.Blog.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	link := blogPath(author.UserName, e.Blog.Slug)
+
+	notifications := make([]models.Notification, len(followerIds))
+	for i, followerId := range followerIds {
+		notifications[i] = models.Notification{
+			UserID:  followerId,
+			Title:   fmt.Sprintf("%s published %q", author.UserName, e.Blog.BlogTitle),
+			Message: utils.TruncateRunes(e.Blog.Summary, notificationExcerptLength),
+			Link:    link,
+			BlogID:  &e.Blog.ID,
+		}
+	}
+	return notifications, nil
+}
+
+// commenterName is the display name of the author of a comment
+func commenterName(comment models.Comment) string {
+	if comment.Author != nil && comment.Author.UserName != "" {
+		return comment.Author.UserName
+	}
+	if comment.GuestName != "" {
+		return comment.GuestName
+	}
+	return "Someone"
+}
+
+// blogPath is the public path of a blog, as shown to readers
+func blogPath(author string, slug string) string {
+	return fmt.Sprintf("/@%s/%s", url.PathEscape(author), url.PathEscape(slug))
+}
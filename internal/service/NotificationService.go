@@ -0,0 +1,260 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"yp-blog-api/internal/dto"
+	"yp-blog-api/internal/mapping"
+	"yp-blog-api/internal/models"
+	"yp-blog-api/internal/pubsub"
+	"yp-blog-api/internal/repository"
+)
+
+const (
+	notificationDefaultLimit = 50
+	notificationMaxLimit     = 200
+	// notificationExcerptLength limits the comment or summary excerpt shown in a notification
+	notificationExcerptLength = 140
+)
+
+// NotificationTypes lists every notification type users can receive
+var NotificationTypes = []string{
+	models.NotificationTypeCommentPosted,
+	models.NotificationTypeCommentApproved,
+	models.NotificationTypeViewMilestone,
+	models.NotificationTypeNewPost,
+}
+
+// NotificationChannels lists every channel notifications are delivered through
+var NotificationChannels = []string{
+	models.NotificationChannelInApp,
+	models.NotificationChannelLive,
+}
+
+var (
+	ErrNotificationNotFound       = errors.New("notification not found")
+	ErrUnknownNotificationType    = errors.New("unknown notification type")
+	ErrUnknownNotificationChannel = errors.New("unknown notification channel")
+)
+
+type NotificationService interface {
+	// Publish delivers an event to its recipients. Failures are logged rather than
+	// returned, so emitting an event never fails the action that caused it.
+	Publish(event NotificationEvent)
+	// Deliver delivers an event to its recipients and reports failures, for callers that retry
+	Deliver(event NotificationEvent) error
+
+	FindNotifications(userId uint, unreadOnly bool, limit int) (*dto.NotificationListDto, error)
+	CountUnread(userId uint) (int64, error)
+	MarkRead(userId uint, id uint) error
+	MarkAllRead(userId uint) error
+	GetPreferences(userId uint) (*dto.NotificationPreferencesDto, error)
+	UpdatePreferences(userId uint, preferencesDto dto.NotificationPreferencesDto) (*dto.NotificationPreferencesDto, error)
+}
+
+type notificationServiceImpl struct {
+	notificationRepo repositories.NotificationRepository
+	followRepo       repositories.FollowRepository
+	userRepo         repositories.UserRepository
+	bus              *pubsub.Bus
+}
+
+// NewNotificationService creates a NotificationService; live notifications are published on bus
+func NewNotificationService(notificationRepo repositories.NotificationRepository, followRepo repositories.FollowRepository, userRepo repositories.UserRepository, bus *pubsub.Bus) NotificationService {
+	return &notificationServiceImpl{
+		notificationRepo: notificationRepo,
+		followRepo:       followRepo,
+		userRepo:         userRepo,
+		bus:              bus,
+	}
+}
+
+func (s *notificationServiceImpl) Publish(event NotificationEvent) {
+	if err := s.Deliver(event); err != nil {
+		log.Printf("Error occurred while publishing %s notifications: %v", event.Type(), err)
+	}
+}
+
+func (s *notificationServiceImpl) Deliver(event NotificationEvent) error {
+	notifications, err := event.notifications(s)
+	if err != nil || len(notifications) == 0 {
+		return err
+	}
+
+	userIds := make([]uint, len(notifications))
+	for i := range notifications {
+		notifications[i].Type = event.Type()
+		userIds[i] = notifications[i].UserID
+	}
+
+	// Keep the notification in the inbox of the recipients who did not switch it off
+	disabled, err := s.notificationRepo.FindDisabledUserIds(userIds, event.Type(), models.NotificationChannelInApp)
+	if err != nil {
+		return err
+	}
+	var inbox []models.Notification
+	var inboxIndexes []int
+	for i, notification := range notifications {
+		if !disabled[notification.UserID] {
+			inbox = append(inbox, notification)
+			inboxIndexes = append(inboxIndexes, i)
+		}
+	}
+	if err := s.notificationRepo.CreateAll(inbox); err != nil {
+		return err
+	}
+	for i, index := range inboxIndexes {
+		notifications[index].ID = inbox[i].ID
+		notifications[index].CreatedAt = inbox[i].CreatedAt
+	}
+
+	// Push it to the connected clients of the recipients who want live notifications.
+	// Live notifications that are not kept in the inbox have no id.
+	disabled, err = s.notificationRepo.FindDisabledUserIds(userIds, event.Type(), models.NotificationChannelLive)
+	if err != nil {
+		return err
+	}
+	for _, notification := range notifications {
+		if !disabled[notification.UserID] {
+			s.bus.Publish(pubsub.Event{
+				Type:   StreamEventNotification,
+				Topics: []string{pubsub.UserTopic(notification.UserID)},
+				Data:   toNotificationDto(notification),
+			})
+		}
+	}
+	return nil
+}
+
+func (s *notificationServiceImpl) FindNotifications(userId uint, unreadOnly bool, limit int) (*dto.NotificationListDto, error) {
+	if limit <= 0 {
+		limit = notificationDefaultLimit
+	}
+	if limit > notificationMaxLimit {
+		limit = notificationMaxLimit
+	}
+	notifications, err := s.notificationRepo.FindAllByUserId(userId, unreadOnly, limit)
+	if err != nil {
+		return nil, err
+	}
+	unread, err := s.notificationRepo.CountUnreadByUserId(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &dto.NotificationListDto{
+		Notifications: make([]dto.NotificationDto, len(notifications)),
+		UnreadCount:   unread,
+	}
+	for i, notification := range notifications {
+		list.Notifications[i] = toNotificationDto(notification)
+	}
+	return list, nil
+}
+
+func (s *notificationServiceImpl) CountUnread(userId uint) (int64, error) {
+	return s.notificationRepo.CountUnreadByUserId(userId)
+}
+
+// MarkRead marks a notification as read; marking it again has no further effect
+func (s *notificationServiceImpl) MarkRead(userId uint, id uint) error {
+	found, err := s.notificationRepo.MarkReadByIdAndUserId(id, userId)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (s *notificationServiceImpl) MarkAllRead(userId uint) error {
+	return s.notificationRepo.MarkAllReadByUserId(userId)
+}
+
+// GetPreferences returns the full preference matrix, with every type and channel enabled
+// unless the user switched it off
+func (s *notificationServiceImpl) GetPreferences(userId uint) (*dto.NotificationPreferencesDto, error) {
+	preferences, err := s.notificationRepo.FindPreferencesByUserId(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	matrix := make(map[string]map[string]bool, len(NotificationTypes))
+	for _, notificationType := range NotificationTypes {
+		matrix[notificationType] = make(map[string]bool, len(NotificationChannels))
+		for _, channel := range NotificationChannels {
+			matrix[notificationType][channel] = true
+		}
+	}
+	for _, preference := range preferences {
+		// Preferences for types or channels that were since removed are ignored
+		if channels, ok := matrix[preference.Type]; ok {
+			if _, ok := channels[preference.Channel]; ok {
+				channels[preference.Channel] = preference.Enabled
+			}
+		}
+	}
+	return &dto.NotificationPreferencesDto{Preferences: matrix}, nil
+}
+
+// UpdatePreferences applies the given entries of the preference matrix
+func (s *notificationServiceImpl) UpdatePreferences(userId uint, preferencesDto dto.NotificationPreferencesDto) (*dto.NotificationPreferencesDto, error) {
+	var preferences []models.NotificationPreference
+	for notificationType, channels := range preferencesDto.Preferences {
+		if !containsString(NotificationTypes, notificationType) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownNotificationType, notificationType)
+		}
+		for channel, enabled := range channels {
+			if !containsString(NotificationChannels, channel) {
+				return nil, fmt.Errorf("%w: %s", ErrUnknownNotificationChannel, channel)
+			}
+			preferences = append(preferences, models.NotificationPreference{
+				UserID:  userId,
+				Type:    notificationType,
+				Channel: channel,
+				Enabled: enabled,
+			})
+		}
+	}
+
+	if err := s.notificationRepo.SavePreferences(preferences); err != nil {
+		return nil, err
+	}
+	return s.GetPreferences(userId)
+}
+
+// blogLink is the public path of a blog, loading its author when needed
+func (s *notificationServiceImpl) blogLink(blog models.Blog) (string, error) {
+	author := blog.Author.UserName
+	if author == "" {
+		user, err := s.userRepo.FindById(blog.AuthorID)
+		if err != nil {
+			return "", err
+		}
+		author = user.UserName
+	}
+	return blogPath(author, blog.Slug), nil
+}
+
+func toNotificationDto(notification models.Notification) dto.NotificationDto {
+	return dto.NotificationDto{
+		ID:        notification.ID,
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Message:   notification.Message,
+		Link:      notification.Link,
+		Read:      notification.ReadAt != nil,
+		CreatedAt: mapper.GetTimeAgo(notification.CreatedAt),
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"net/url"
+	"strings"
+	"yp-blog-api/internal/dto"
+	"yp-blog-api/internal/models"
+	"yp-blog-api/internal/repository"
+)
+
+const (
+	// oEmbedCardWidth and oEmbedCardHeight are the preferred size of an embedded post card
+	oEmbedCardWidth  = 600
+	oEmbedCardHeight = 400
+	// oEmbedCacheAge is how long consumers may cache an embed, in seconds
+	oEmbedCacheAge = 3600
+)
+
+// ErrOEmbedURLNotSupported is returned for URLs that do not point at a post on this site
+var ErrOEmbedURLNotSupported = errors.New("url does not point at a post on this site")
+
+// OEmbedService describes posts to sites that embed them, following the oEmbed spec
+type OEmbedService interface {
+	// FindEmbed resolves the URL of a post to its embed card. maxWidth and maxHeight are
+	// the consumer's size limits, zero when it has none. The site is the configured site
+	// URL, or fallbackSiteURL when none is configured.
+	FindEmbed(postURL string, maxWidth int, maxHeight int, fallbackSiteURL string) (*dto.OEmbedDto, error)
+}
+
+type oEmbedServiceImpl struct {
+	blogRepo  repositories.BlogRepository
+	media     MediaService
+	siteURL   string
+	siteTitle string
+}
+
+// NewOEmbedService creates an OEmbedService. siteURL is the public address of the site
+// the posts are read on; siteTitle is the provider name.
+func NewOEmbedService(blogRepo repositories.BlogRepository, media MediaService, siteURL string, siteTitle string) OEmbedService {
+	if siteTitle == "" {
+		siteTitle = "YP Blog"
+	}
+	return &oEmbedServiceImpl{
+		blogRepo:  blogRepo,
+		media:     media,
+		siteURL:   strings.TrimSuffix(siteURL, "/"),
+		siteTitle: siteTitle,
+	}
+}
+
+func (s *oEmbedServiceImpl) FindEmbed(postURL string, maxWidth int, maxHeight int, fallbackSiteURL string) (*dto.OEmbedDto, error) {
+	siteURL := s.siteURL
+	if siteURL == "" {
+		siteURL = strings.TrimSuffix(fallbackSiteURL, "/")
+	}
+	author, slug, err := parsePostURL(postURL, siteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	blog, err := s.blogRepo.FindByUsernameAndSlug(author, slug)
+	if err != nil {
+		// Links shared before a rename keep embedding the post
+		moved, historyErr := s.blogRepo.FindByPreviousUsernameOrSlug(author, slug)
+		if historyErr != nil {
+			return nil, ErrBlogNotFound
+		}
+		blog = moved
+	}
+	if !blog.Published || blog.IsDeleted {
+		return nil, ErrBlogNotFound
+	}
+
+	width, height := oEmbedCardWidth, oEmbedCardHeight
+	if maxWidth > 0 && maxWidth < width {
+		width = maxWidth
+	}
+	if maxHeight > 0 && maxHeight < height {
+		height = maxHeight
+	}
+	embed := &dto.OEmbedDto{
+		Type:         "rich",
+		Version:      "1.0",
+		Title:        blog.BlogTitle,
+		AuthorName:   blog.Author.UserName,
+		AuthorURL:    siteURL + authorPath(blog.Author.UserName),
+		ProviderName: s.siteTitle,
+		ProviderURL:  siteURL + "/",
+		CacheAge:     oEmbedCacheAge,
+		ThumbnailURL: absoluteURL(siteURL, blog.Thumbnail),
+		Width:        width,
+		Height:       height,
+		Description:  blog.Summary,
+		ReadingTime:  blog.MinRead,
+	}
+	// The size of the thumbnail is known when it is an upload
+	if images, err := s.media.FindThumbnailImages([]uint{blog.ID}); err != nil {
+		log.Printf("Error occurred while loading the thumbnail of blog %d: %v", blog.ID, err)
+	} else if thumbnail := images[blog.ID]; thumbnail != nil && absoluteURL(siteURL, thumbnail.Src) == embed.ThumbnailURL {
+		embed.ThumbnailWidth, embed.ThumbnailHeight = thumbnail.Width, thumbnail.Height
+	}
+	embed.HTML = renderEmbedCard(blog, siteURL+blogPath(blog.Author.UserName, blog.Slug), embed)
+	return embed, nil
+}
+
+// parsePostURL extracts the author and slug of a post URL of the form <site>/@author/slug
+func parsePostURL(postURL string, siteURL string) (string, string, error) {
+	parsed, err := url.Parse(postURL)
+	if err != nil || parsed.Host == "" {
+		return "", "", ErrOEmbedURLNotSupported
+	}
+	site, err := url.Parse(siteURL)
+	if err != nil || !strings.EqualFold(parsed.Host, site.Host) {
+		return "", "", ErrOEmbedURLNotSupported
+	}
+	path := strings.TrimPrefix(strings.TrimSuffix(parsed.Path, "/"), strings.TrimSuffix(site.Path, "/"))
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(segments) != 2 || !strings.HasPrefix(segments[0], "@") || len(segments[0]) == 1 || segments[1] == "" {
+		return "", "", ErrOEmbedURLNotSupported
+	}
+	return strings.TrimPrefix(segments[0], "@"), segments[1], nil
+}
+
+// renderEmbedCard renders the self-contained HTML card that consumers insert into their page
+func renderEmbedCard(blog models.Blog, link string, embed *dto.OEmbedDto) string {
+	var card strings.Builder
+	fmt.Fprintf(&card, `<blockquote class="yp-blog-embed" style="max-width:%dpx;margin:0;">`, embed.Width)
+	if embed.ThumbnailURL != "" {
+		fmt.Fprintf(&card, `<a href="%s"><img src="%s" alt="" style="max-width:100%%;"></a>`,
+			html.EscapeString(link), html.EscapeString(embed.ThumbnailURL))
+	}
+	fmt.Fprintf(&card, `<p><strong><a href="%s">%s</a></strong></p>`, html.EscapeString(link), html.EscapeString(blog.BlogTitle))
+	if blog.Summary != "" {
+		fmt.Fprintf(&card, `<p>%s</p>`, html.EscapeString(blog.Summary))
+	}
+	fmt.Fprintf(&card, `<p>By <a href="%s">@%s</a>`, html.EscapeString(embed.AuthorURL), html.EscapeString(blog.Author.UserName))
+	if blog.MinRead > 0 {
+		fmt.Fprintf(&card, ` &middot; %d min read`, blog.MinRead)
+	}
+	fmt.Fprintf(&card, ` &middot; <a href="%s">%s</a></p></blockquote>`, html.EscapeString(embed.ProviderURL), html.EscapeString(embed.ProviderName))
+	return card.String()
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	"yp-blog-api/internal/models"
+	"yp-blog-api/internal/repository"
+	"yp-blog-api/internal/utils"
+)
+
+const (
+	// outboxBatchSize is how many pending events DispatchPending handles per call
+	outboxBatchSize = 100
+	// outboxRetryBaseDelay is the wait after the first failed attempt; it doubles with every attempt
+	outboxRetryBaseDelay = 5 * time.Second
+	// outboxRetryMaxDelay caps the wait between two attempts
+	outboxRetryMaxDelay = time.Hour
+	// outboxMaxAttempts is how many times an event is dispatched before it is dead, about
+	// a day of retries
+	outboxMaxAttempts = 30
+	// outboxRetention is how long processed events are kept
+	outboxRetention = 7 * 24 * time.Hour
+)
+
+// OutboxHandler reacts to an outbox event. Delivery is at-least-once, so a handler
+// may see the same event again and should tolerate it.
+type OutboxHandler func(event models.OutboxEvent) error
+
+// OutboxDispatcher hands the events recorded in the outbox to the registered handlers
+type OutboxDispatcher interface {
+	// Register adds a handler for an event type. The name identifies the handler in
+	// the outbox, so it must be unique and stable across restarts.
+	Register(eventType string, name string, handler OutboxHandler)
+	// Notify asks Run to dispatch right away instead of at the next tick
+	Notify()
+	// DispatchPending hands the due events to their handlers once
+	DispatchPending() error
+	// Run dispatches pending events every interval, and whenever Notify is called
+	Run(interval time.Duration)
+}
+
+type outboxHandlerRegistration struct {
+	name    string
+	handler OutboxHandler
+}
+
+type outboxDispatcherImpl struct {
+	outboxRepo repositories.OutboxRepository
+	mu         sync.RWMutex
+	handlers   map[string][]outboxHandlerRegistration
+	wake       chan struct{}
+}
+
+// NewOutboxDispatcher creates an OutboxDispatcher
+func NewOutboxDispatcher(outboxRepo repositories.OutboxRepository) OutboxDispatcher {
+	return &outboxDispatcherImpl{
+		outboxRepo: outboxRepo,
+		handlers:   make(map[string][]outboxHandlerRegistration),
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+func (d *outboxDispatcherImpl) Register(eventType string, name string, handler OutboxHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers[eventType] = append(d.handlers[eventType], outboxHandlerRegistration{name: name, handler: handler})
+}
+
+func (d *outboxDispatcherImpl) Notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+		// A dispatch is already requested
+	}
+}
+
+func (d *outboxDispatcherImpl) Run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-d.wake:
+		}
+		if err := d.DispatchPending(); err != nil {
+			log.Printf("Error occurred while dispatching outbox events: %v", err)
+		}
+	}
+}
+
+func (d *outboxDispatcherImpl) DispatchPending() error {
+	events, err := d.outboxRepo.FindPending(time.Now(), outboxBatchSize)
+	if err != nil {
+		return err
+	}
+	for i := range events {
+		if err := d.dispatch(&events[i]); err != nil {
+			return err
+		}
+	}
+	return d.outboxRepo.DeleteProcessedBefore(time.Now().Add(-outboxRetention))
+}
+
+// dispatch runs the handlers of an event that did not succeed yet. The event is
+// processed once all of them succeeded; otherwise it is retried with backoff until it
+// runs out of attempts. A handler that finds the event's records gone is done with it,
+// as no retry would bring them back.
+func (d *outboxDispatcherImpl) dispatch(event *models.OutboxEvent) error {
+	d.mu.RLock()
+	registrations := d.handlers[event.Type]
+	d.mu.RUnlock()
+
+	var completed []string
+	if event.CompletedHandlers != "" {
+		completed = strings.Split(event.CompletedHandlers, ",")
+	}
+	var failures []string
+	for _, registration := range registrations {
+		if containsString(completed, registration.name) {
+			continue
+		}
+		if err := registration.handler(*event); errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("Skipped handler %s of outbox event %d (%s), its records are gone: %v", registration.name, event.ID, event.Type, err)
+		} else if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", registration.name, err))
+			continue
+		}
+		completed = append(completed, registration.name)
+	}
+
+	event.Attempts++
+	event.CompletedHandlers = strings.Join(completed, ",")
+	if len(failures) == 0 {
+		now := time.Now()
+		event.ProcessedAt = &now
+		event.LastError = ""
+	} else {
+		event.LastError = utils.TruncateRunes(strings.Join(failures, "; "), 500)
+		event.NextAttemptAt = time.Now().Add(utils.ExponentialBackoff(outboxRetryBaseDelay, outboxRetryMaxDelay, event.Attempts))
+		log.Printf("Error occurred while handling outbox event %d (%s): %s", event.ID, event.Type, event.LastError)
+		if event.Attempts >= outboxMaxAttempts {
+			now := time.Now()
+			event.DeadAt = &now
+			log.Printf("Gave up on outbox event %d (%s) after %d attempts", event.ID, event.Type, event.Attempts)
+		}
+	}
+	return d.outboxRepo.Update(event)
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"yp-blog-api/internal/dto"
+	"yp-blog-api/internal/models"
+	"yp-blog-api/internal/repository"
+)
+
+// DefaultReactionTypes are used when no reaction types are configured
+var DefaultReactionTypes = []string{"like", "love", "clap", "insightful"}
+
+var (
+	ErrUnknownReactionType = errors.New("unknown reaction type")
+	ErrReactorRequired     = errors.New("a signed-in user or a valid visitor id is required to react")
+)
+
+var visitorIdFormat = regexp.MustCompile(`^[A-Za-z0-9_-]{8,64}$`)
+
+// Reactor identifies who reacts to a blog: a signed-in user or an anonymous visitor
+type Reactor struct {
+	UserID    *uint
+	VisitorID string
+}
+
+// key returns the identity a reaction is stored under, or "" when the reactor is unknown
+func (r Reactor) key() string {
+	if r.UserID != nil {
+		return fmt.Sprintf("user:%d", *r.UserID)
+	}
+	if visitorIdFormat.MatchString(r.VisitorID) {
+		return "visitor:" + r.VisitorID
+	}
+	return ""
+}
+
+type ReactionService interface {
+	GetReactions(author string, slug string, reactor Reactor) (*dto.ReactionSummaryDto, error)
+	AddReaction(author string, slug string, reactor Reactor, reactionType string) (*dto.ReactionSummaryDto, error)
+	RemoveReaction(author string, slug string, reactor Reactor, reactionType string) (*dto.ReactionSummaryDto, error)
+	// CountByBlogIds counts the reactions of each blog by type, leaving out types that are
+	// no longer configured so every listing agrees with the reaction summary
+	CountByBlogIds(blogIds []uint) (map[uint]map[string]int64, error)
+}
+
+type reactionServiceImpl struct {
+	reactionRepo  repositories.ReactionRepository
+	blogRepo      repositories.BlogRepository
+	reactionTypes []string
+}
+
+// NewReactionService creates a ReactionService accepting the given reaction types
+func NewReactionService(reactionRepo repositories.ReactionRepository, blogRepo repositories.BlogRepository, reactionTypes []string) ReactionService {
+	return &reactionServiceImpl{
+		reactionRepo:  reactionRepo,
+		blogRepo:      blogRepo,
+		reactionTypes: normalizeReactionTypes(reactionTypes),
+	}
+}
+
+func (s *reactionServiceImpl) GetReactions(author string, slug string, reactor Reactor) (*dto.ReactionSummaryDto, error) {
+	blog, err := s.findReactableBlog(author, slug)
+	if err != nil {
+		return nil, err
+	}
+	return s.summary(blog.ID, reactor.key())
+}
+
+// AddReaction records a reaction; adding the same reaction twice has no further effect
+func (s *reactionServiceImpl) AddReaction(author string, slug string, reactor Reactor, reactionType string) (*dto.ReactionSummaryDto, error) {
+	blog, reactorKey, err := s.prepare(author, slug, reactor, reactionType)
+	if err != nil {
+		return nil, err
+	}
+
+	reaction := models.Reaction{
+		BlogID:     blog.ID,
+		UserID:     reactor.UserID,
+		ReactorKey: reactorKey,
+		Type:       reactionType,
+	}
+	if reactor.UserID == nil {
+		reaction.VisitorID = reactor.VisitorID
+	}
+	if err := s.reactionRepo.CreateIfNotExists(&reaction); err != nil {
+		return nil, fmt.Errorf("error saving reaction: %v", err)
+	}
+	return s.summary(blog.ID, reactorKey)
+}
+
+// RemoveReaction withdraws a reaction; removing a missing reaction has no effect
+func (s *reactionServiceImpl) RemoveReaction(author string, slug string, reactor Reactor, reactionType string) (*dto.ReactionSummaryDto, error) {
+	blog, reactorKey, err := s.prepare(author, slug, reactor, reactionType)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.reactionRepo.DeleteByBlogIdAndReactorKeyAndType(blog.ID, reactorKey, reactionType); err != nil {
+		return nil, fmt.Errorf("error removing reaction: %v", err)
+	}
+	return s.summary(blog.ID, reactorKey)
+}
+
+// prepare validates the reaction type and reactor and resolves the blog
+func (s *reactionServiceImpl) prepare(author string, slug string, reactor Reactor, reactionType string) (models.Blog, string, error) {
+	if !s.isKnownType(reactionType) {
+		return models.Blog{}, "", ErrUnknownReactionType
+	}
+	reactorKey := reactor.key()
+	if reactorKey == "" {
+		return models.Blog{}, "", ErrReactorRequired
+	}
+	blog, err := s.findReactableBlog(author, slug)
+	if err != nil {
+		return models.Blog{}, "", err
+	}
+	return blog, reactorKey, nil
+}
+
+func (s *reactionServiceImpl) CountByBlogIds(blogIds []uint) (map[uint]map[string]int64, error) {
+	counts, err := s.reactionRepo.CountByBlogIds(blogIds)
+	if err != nil {
+		return nil, err
+	}
+	for _, blogCounts := range counts {
+		for reactionType := range blogCounts {
+			if !s.isKnownType(reactionType) {
+				delete(blogCounts, reactionType)
+			}
+		}
+	}
+	return counts, nil
+}
+
+// summary builds the reaction counts of a blog, listing every configured type
+func (s *reactionServiceImpl) summary(blogId uint, reactorKey string) (*dto.ReactionSummaryDto, error) {
+	counts, err := s.CountByBlogIds([]uint{blogId})
+	if err != nil {
+		return nil, err
+	}
+	summary := dto.ReactionSummaryDto{Counts: reactionCounts(counts[blogId], s.reactionTypes), Reacted: []string{}}
+	for _, count := range summary.Counts {
+		summary.Total += count
+	}
+	if reactorKey != "" {
+		reacted, err := s.reactionRepo.FindTypesByBlogIdAndReactorKey(blogId, reactorKey)
+		if err != nil {
+			return nil, err
+		}
+		summary.Reacted = append(summary.Reacted, reacted...)
+	}
+	return &summary, nil
+}
+
+func (s *reactionServiceImpl) isKnownType(reactionType string) bool {
+	for _, known := range s.reactionTypes {
+		if known == reactionType {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *reactionServiceImpl) findReactableBlog(author string, slug string) (models.Blog, error) {
+	blog, err := s.blogRepo.FindByUsernameAndSlug(author, slug)
+	if err != nil || !blog.Published || blog.IsDeleted {
+		return models.Blog{}, fmt.Errorf("blog by author '%s' and slug '%s' could not be found: %w", author, slug, ErrBlogNotFound)
+	}
+	return blog, nil
+}
+
+// reactionCounts lists the count of every configured reaction type, including zeros
+func reactionCounts(counts map[string]int64, reactionTypes []string) map[string]int64 {
+	result := make(map[string]int64, len(reactionTypes))
+	for _, reactionType := range reactionTypes {
+		result[reactionType] = counts[reactionType]
+	}
+	return result
+}
+
+// normalizeReactionTypes lowercases and de-duplicates the configured reaction types
+func normalizeReactionTypes(reactionTypes []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, reactionType := range reactionTypes {
+		reactionType = strings.ToLower(strings.TrimSpace(reactionType))
+		if reactionType != "" && !seen[reactionType] {
+			seen[reactionType] = true
+			result = append(result, reactionType)
+		}
+	}
+	if len(result) == 0 {
+		return DefaultReactionTypes
+	}
+	return result
+}
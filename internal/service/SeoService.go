@@ -0,0 +1,161 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"yp-blog-api/internal/dto"
+	"yp-blog-api/internal/mapping"
+	"yp-blog-api/internal/repository"
+	"yp-blog-api/internal/utils"
+)
+
+const (
+	// seoDescriptionLength is how much of the post is used when it has neither an SEO description nor a summary
+	seoDescriptionLength = 160
+	// seoHeadlineLength is the longest headline search engines show for a BlogPosting
+	seoHeadlineLength = 110
+)
+
+// SeoService builds the metadata pages put in their head for search engines and link previews
+type SeoService interface {
+	// FindSeoMetadataByAuthorAndSlug builds the metadata of a published blog. URLs point at
+	// the configured site URL, or at fallbackSiteURL when none is configured.
+	FindSeoMetadataByAuthorAndSlug(author string, slug string, fallbackSiteURL string) (*dto.SeoMetadataDto, error)
+}
+
+type seoServiceImpl struct {
+	blogRepo   repositories.BlogRepository
+	blogMapper mapper.BlogMapper
+	siteURL    string
+	siteTitle  string
+}
+
+// NewSeoService creates a SeoService. siteURL is the public address of the site the
+// posts are read on; siteTitle is the site name shown in link previews.
+func NewSeoService(blogRepo repositories.BlogRepository, blogMapper mapper.BlogMapper, siteURL string, siteTitle string) SeoService {
+	if siteTitle == "" {
+		siteTitle = "YP Blog"
+	}
+	return &seoServiceImpl{
+		blogRepo:   blogRepo,
+		blogMapper: blogMapper,
+		siteURL:    strings.TrimSuffix(siteURL, "/"),
+		siteTitle:  siteTitle,
+	}
+}
+
+func (s *seoServiceImpl) FindSeoMetadataByAuthorAndSlug(author string, slug string, fallbackSiteURL string) (*dto.SeoMetadataDto, error) {
+	blog, err := s.blogRepo.FindByUsernameAndSlug(author, slug)
+	if err != nil {
+		if moved, historyErr := s.blogRepo.FindByPreviousUsernameOrSlug(author, slug); historyErr == nil {
+			return nil, &BlogMovedError{Author: moved.Author.UserName, Slug: moved.Slug}
+		}
+		return nil, fmt.Errorf("blog by author '%s' and slug '%s' could not be found: %w", author, slug, ErrBlogNotFound)
+	}
+	// Drafts and deleted posts must not be previewed or indexed
+	if !blog.Published || blog.IsDeleted {
+		return nil, ErrBlogNotFound
+	}
+
+	siteURL := s.siteURL
+	if siteURL == "" {
+		siteURL = strings.TrimSuffix(fallbackSiteURL, "/")
+	}
+	return buildSeoMetadata(s.blogMapper.BlogToBlogDetailDto(blog), siteURL, s.siteTitle), nil
+}
+
+// buildSeoMetadata derives the canonical URL, Open Graph and Twitter card tags and the
+// BlogPosting JSON-LD of a blog, preferring the SEO overrides over the title and summary
+func buildSeoMetadata(blog dto.BlogDetailDto, siteURL string, siteTitle string) *dto.SeoMetadataDto {
+	title := blog.SeoTitle
+	if title == "" {
+		title = blog.BlogTitle
+	}
+	description := blog.SeoDescription
+	if description == "" {
+		description = blog.Summary
+	}
+	if description == "" {
+		description = utils.TruncateRunes(strings.Join(strings.Fields(utils.StripHTML(blog.BlogContent)), " "), seoDescriptionLength)
+	}
+	canonicalURL := siteURL + blogPath(blog.Author.UserName, blog.Slug)
+	authorURL := siteURL + authorPath(blog.Author.UserName)
+	image := absoluteURL(siteURL, blog.Thumbnail)
+
+	openGraph := []dto.SeoMetaTagDto{
+		{Property: "og:type", Content: "article"},
+		{Property: "og:site_name", Content: siteTitle},
+		{Property: "og:title", Content: title},
+		{Property: "og:description", Content: description},
+		{Property: "og:url", Content: canonicalURL},
+	}
+	if image != "" {
+		openGraph = append(openGraph, dto.SeoMetaTagDto{Property: "og:image", Content: image})
+	}
+	openGraph = append(openGraph,
+		dto.SeoMetaTagDto{Property: "article:published_time", Content: blog.PublishedAt},
+		dto.SeoMetaTagDto{Property: "article:modified_time", Content: blog.ModifiedAt},
+		dto.SeoMetaTagDto{Property: "article:author", Content: authorURL},
+	)
+	var sections, keywords []string
+	for _, category := range blog.Categories {
+		sections = append(sections, category.Title)
+		openGraph = append(openGraph, dto.SeoMetaTagDto{Property: "article:section", Content: category.Title})
+	}
+	for _, tag := range blog.Tags {
+		keywords = append(keywords, tag.Title)
+		openGraph = append(openGraph, dto.SeoMetaTagDto{Property: "article:tag", Content: tag.Title})
+	}
+
+	// Posts with a thumbnail get the large preview card
+	card := "summary"
+	if image != "" {
+		card = "summary_large_image"
+	}
+	twitter := []dto.SeoMetaTagDto{
+		{Name: "twitter:card", Content: card},
+		{Name: "twitter:title", Content: title},
+		{Name: "twitter:description", Content: description},
+	}
+	if image != "" {
+		twitter = append(twitter, dto.SeoMetaTagDto{Name: "twitter:image", Content: image})
+	}
+
+	jsonLD := dto.BlogPostingJSONLDDto{
+		Context:          "https://schema.org",
+		Type:             "BlogPosting",
+		Headline:         utils.TruncateRunes(title, seoHeadlineLength),
+		Description:      description,
+		URL:              canonicalURL,
+		DatePublished:    blog.PublishedAt,
+		DateModified:     blog.ModifiedAt,
+		Author:           dto.JSONLDThingDto{Type: "Person", Name: blog.Author.UserName, URL: authorURL},
+		Publisher:        dto.JSONLDThingDto{Type: "Organization", Name: siteTitle, URL: siteURL + "/"},
+		MainEntityOfPage: dto.JSONLDThingDto{Type: "WebPage", ID: canonicalURL},
+		ArticleSection:   sections,
+		Keywords:         keywords,
+	}
+	if image != "" {
+		jsonLD.Image = []string{image}
+	}
+	if blog.MinRead > 0 {
+		jsonLD.TimeRequired = fmt.Sprintf("PT%dM", blog.MinRead)
+	}
+
+	return &dto.SeoMetadataDto{
+		Title:        title,
+		Description:  description,
+		CanonicalURL: canonicalURL,
+		OpenGraph:    openGraph,
+		Twitter:      twitter,
+		JSONLD:       jsonLD,
+	}
+}
+
+// absoluteURL resolves a site-relative path against the site URL, leaving absolute URLs as they are
+func absoluteURL(siteURL string, location string) string {
+	if strings.HasPrefix(location, "/") && !strings.HasPrefix(location, "//") {
+		return siteURL + location
+	}
+	return location
+}
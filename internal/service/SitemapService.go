@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"yp-blog-api/internal/dto"
+	"yp-blog-api/internal/models"
+	"yp-blog-api/internal/repository"
+	"yp-blog-api/internal/syndication"
+)
+
+// JobTypeSitemapRebuild rebuilds every sitemap entry from the published blogs
+const JobTypeSitemapRebuild = "sitemap.rebuild"
+
+var ErrSitemapPageNotFound = errors.New("sitemap page not found")
+
+// SitemapPage is one sitemap of the sitemap index
+type SitemapPage struct {
+	Number  int
+	LastMod time.Time
+}
+
+// SitemapService keeps the sitemap of posts, category pages and author pages. The
+// entries are stored, updated as blogs change and rebuilt in the background.
+type SitemapService interface {
+	// FindSitemapPages lists the sitemaps of the index; there is always at least one
+	FindSitemapPages() ([]SitemapPage, error)
+	// FindSitemapPage lists the URLs of a sitemap, numbered from 1. URLs point at the
+	// configured site URL, or at fallbackSiteURL when none is configured.
+	FindSitemapPage(number int, fallbackSiteURL string) ([]syndication.SitemapURL, error)
+	// HasSiteURL reports whether a site URL is configured
+	HasSiteURL() bool
+	// Rebuild replaces every entry with the ones derived from the published blogs
+	Rebuild() error
+	// ScheduleRebuild queues a background rebuild
+	ScheduleRebuild() (*dto.JobDto, error)
+}
+
+type sitemapServiceImpl struct {
+	sitemapRepo repositories.SitemapRepository
+	blogRepo    repositories.BlogRepository
+	jobs        JobQueue
+	siteURL     string
+}
+
+// NewSitemapService creates a SitemapService and subscribes it to blog changes
+func NewSitemapService(sitemapRepo repositories.SitemapRepository, blogRepo repositories.BlogRepository, outbox OutboxDispatcher,
+	jobs JobQueue, siteURL string) SitemapService {
+	s := &sitemapServiceImpl{
+		sitemapRepo: sitemapRepo,
+		blogRepo:    blogRepo,
+		jobs:        jobs,
+		siteURL:     strings.TrimSuffix(siteURL, "/"),
+	}
+	for _, eventType := range []string{models.EventBlogPublished, models.EventBlogUpdated, models.EventBlogDeleted} {
+		outbox.Register(eventType, "sitemap", s.handleBlogEvent)
+	}
+	HandleJob(jobs, JobTypeSitemapRebuild, func(ctx context.Context, _ struct{}) error {
+		return s.Rebuild()
+	})
+	return s
+}
+
+func (s *sitemapServiceImpl) HasSiteURL() bool {
+	return s.siteURL != ""
+}
+
+func (s *sitemapServiceImpl) FindSitemapPages() ([]SitemapPage, error) {
+	count, err := s.sitemapRepo.Count()
+	if err != nil {
+		return nil, err
+	}
+	pageCount := int((count + syndication.MaxSitemapURLs - 1) / syndication.MaxSitemapURLs)
+	if pageCount == 0 {
+		pageCount = 1
+	}
+	pages := make([]SitemapPage, pageCount)
+	for i := range pages {
+		lastMod, err := s.sitemapRepo.FindPageLastMod(i*syndication.MaxSitemapURLs, syndication.MaxSitemapURLs)
+		if err != nil {
+			return nil, err
+		}
+		pages[i] = SitemapPage{Number: i + 1, LastMod: lastMod}
+	}
+	return pages, nil
+}
+
+func (s *sitemapServiceImpl) FindSitemapPage(number int, fallbackSiteURL string) ([]syndication.SitemapURL, error) {
+	if number < 1 {
+		return nil, ErrSitemapPageNotFound
+	}
+	entries, err := s.sitemapRepo.FindPage((number-1)*syndication.MaxSitemapURLs, syndication.MaxSitemapURLs)
+	if err != nil {
+		return nil, err
+	}
+	// The first page exists even while the sitemap is empty
+	if len(entries) == 0 && number > 1 {
+		return nil, ErrSitemapPageNotFound
+	}
+
+	siteURL := s.siteURL
+	if siteURL == "" {
+		siteURL = strings.TrimSuffix(fallbackSiteURL, "/")
+	}
+	urls := make([]syndication.SitemapURL, len(entries))
+	for i, entry := range entries {
+		urls[i] = syndication.SitemapURL{Loc: siteURL + entry.Path, LastMod: entry.LastMod}
+	}
+	return urls, nil
+}
+
+func (s *sitemapServiceImpl) Rebuild() error {
+	blogs, err := s.blogRepo.FindLatestPublished(repositories.BlogFilter{}, -1)
+	if err != nil {
+		return err
+	}
+
+	// Listing pages come first and posts oldest first, so new posts are appended to the last page
+	var listings, posts []models.SitemapEntry
+	listingIndex := make(map[string]int)
+	addListing := func(kind string, refId uint, path string, lastMod time.Time) {
+		key := fmt.Sprintf("%s:%d", kind, refId)
+		if i, ok := listingIndex[key]; ok {
+			if lastMod.After(listings[i].LastMod) {
+				listings[i].LastMod = lastMod
+			}
+			return
+		}
+		listingIndex[key] = len(listings)
+		listings = append(listings, models.SitemapEntry{Kind: kind, RefID: refId, Path: path, LastMod: lastMod})
+	}
+	for i := len(blogs) - 1; i >= 0; i-- {
+		blog := blogs[i]
+		posts = append(posts, models.SitemapEntry{
+			Kind:    models.SitemapKindPost,
+			RefID:   blog.ID,
+			Path:    blogPath(blog.Author.UserName, blog.Slug),
+			LastMod: blog.UpdatedAt,
+		})
+		addListing(models.SitemapKindAuthor, blog.AuthorID, authorPath(blog.Author.UserName), blog.UpdatedAt)
+		for _, category := range blog.Categories {
+			addListing(models.SitemapKindCategory, category.ID, categoryPath(category.Slug), blog.UpdatedAt)
+		}
+	}
+	return s.sitemapRepo.ReplaceAll(append(listings, posts...))
+}
+
+func (s *sitemapServiceImpl) ScheduleRebuild() (*dto.JobDto, error) {
+	job, err := s.jobs.Enqueue(JobTypeSitemapRebuild, struct{}{}, JobOptions{UniqueKey: JobTypeSitemapRebuild})
+	if err != nil {
+		return nil, err
+	}
+	jobDto := toJobDto(*job)
+	return &jobDto, nil
+}
+
+// handleBlogEvent updates the entries of a changed blog and of the pages listing it
+func (s *sitemapServiceImpl) handleBlogEvent(event models.OutboxEvent) error {
+	blog, err := s.blogRepo.FindByOutboxEvent(event)
+	if err != nil {
+		return err
+	}
+
+	if blog.Published && !blog.IsDeleted {
+		entries := []models.SitemapEntry{
+			{Kind: models.SitemapKindPost, RefID: blog.ID, Path: blogPath(blog.Author.UserName, blog.Slug), LastMod: blog.UpdatedAt},
+			{Kind: models.SitemapKindAuthor, RefID: blog.AuthorID, Path: authorPath(blog.Author.UserName), LastMod: blog.UpdatedAt},
+		}
+		for _, category := range blog.Categories {
+			entries = append(entries, models.SitemapEntry{
+				Kind: models.SitemapKindCategory, RefID: category.ID, Path: categoryPath(category.Slug), LastMod: blog.UpdatedAt,
+			})
+		}
+		for i := range entries {
+			if err := s.sitemapRepo.Save(&entries[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// The blog left the site: drop it, and drop the listings it was the last post of
+	if err := s.sitemapRepo.DeleteByRef(models.SitemapKindPost, blog.ID); err != nil {
+		return err
+	}
+	if err := s.refreshListing(models.SitemapKindAuthor, blog.AuthorID, authorPath(blog.Author.UserName),
+		repositories.BlogFilter{AuthorID: blog.AuthorID}); err != nil {
+		return err
+	}
+	for _, category := range blog.Categories {
+		if err := s.refreshListing(models.SitemapKindCategory, category.ID, categoryPath(category.Slug),
+			repositories.BlogFilter{CategorySlug: category.Slug}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refreshListing marks a listing page as changed now, or removes it when no published blog is left on it
+func (s *sitemapServiceImpl) refreshListing(kind string, refId uint, path string, filter repositories.BlogFilter) error {
+	remaining, err := s.blogRepo.FindLatestPublished(filter, 1)
+	if err != nil {
+		return err
+	}
+	if len(remaining) == 0 {
+		return s.sitemapRepo.DeleteByRef(kind, refId)
+	}
+	return s.sitemapRepo.Save(&models.SitemapEntry{Kind: kind, RefID: refId, Path: path, LastMod: time.Now()})
+}
+
+// authorPath is the path of an author's page on the site
+func authorPath(author string) string {
+	return "/@" + url.PathEscape(author)
+}
+
+// categoryPath is the path of a category's page on the site
+func categoryPath(slug string) string {
+	return "/category/" + url.PathEscape(slug)
+}
@@ -0,0 +1,13 @@
+package service
+
+// Types of the live events services publish on the pub/sub bus
+const (
+	// StreamEventNewPost carries a dto.BlogCardDto of a newly published blog
+	StreamEventNewPost = "new_post"
+	// StreamEventNewComment carries a dto.CommentDto of a comment that went live
+	StreamEventNewComment = "new_comment"
+	// StreamEventViewCount carries a dto.ViewCountDto whenever a blog is viewed
+	StreamEventViewCount = "view_count"
+	// StreamEventNotification carries a dto.NotificationDto for its recipient
+	StreamEventNotification = "notification"
+)
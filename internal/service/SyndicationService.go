@@ -0,0 +1,182 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"yp-blog-api/internal/models"
+	"yp-blog-api/internal/repository"
+	"yp-blog-api/internal/syndication"
+	"yp-blog-api/internal/utils"
+)
+
+const (
+	// FeedModeSummary syndicates the summary of each post
+	FeedModeSummary = "summary"
+	// FeedModeFull syndicates the full HTML content of each post
+	FeedModeFull = "full"
+
+	defaultFeedLimit = 20
+	maxFeedLimit     = 100
+	// feedSummaryLength is the length of the summary generated for posts without one
+	feedSummaryLength = 300
+)
+
+var (
+	ErrFeedCategoryNotFound = errors.New("category not found")
+	ErrFeedTagNotFound      = errors.New("tag not found")
+	ErrFeedAuthorNotFound   = errors.New("author not found")
+	ErrUnknownFeedMode      = errors.New("mode must be summary or full")
+)
+
+// FeedQuery selects the posts of a feed. At most one of Category, Tag and Author is
+// expected; combining them narrows the feed down further.
+type FeedQuery struct {
+	// Category is a category slug
+	Category string
+	// Tag is a tag title
+	Tag string
+	// Author is an author's username
+	Author string
+	Mode   string
+	Limit  int
+}
+
+// SyndicationService builds the RSS, Atom and JSON feeds of published posts
+type SyndicationService interface {
+	// BuildFeed builds the feed served at feedURL. Links point at siteURL, or at
+	// fallbackSiteURL when no site URL is configured.
+	BuildFeed(query FeedQuery, fallbackSiteURL string, feedURL string) (*syndication.Feed, error)
+	// HasSiteURL reports whether a site URL is configured
+	HasSiteURL() bool
+}
+
+type syndicationServiceImpl struct {
+	blogRepo     repositories.BlogRepository
+	categoryRepo repositories.CategoryRepository
+	tagRepo      repositories.TagRepository
+	userRepo     repositories.UserRepository
+	siteURL      string
+	siteTitle    string
+}
+
+// NewSyndicationService creates a SyndicationService. siteURL is the public address of
+// the site the posts are read on; siteTitle names the feeds.
+func NewSyndicationService(blogRepo repositories.BlogRepository, categoryRepo repositories.CategoryRepository,
+	tagRepo repositories.TagRepository, userRepo repositories.UserRepository, siteURL string, siteTitle string) SyndicationService {
+	if siteTitle == "" {
+		siteTitle = "YP Blog"
+	}
+	return &syndicationServiceImpl{
+		blogRepo:     blogRepo,
+		categoryRepo: categoryRepo,
+		tagRepo:      tagRepo,
+		userRepo:     userRepo,
+		siteURL:      strings.TrimSuffix(siteURL, "/"),
+		siteTitle:    siteTitle,
+	}
+}
+
+func (s *syndicationServiceImpl) HasSiteURL() bool {
+	return s.siteURL != ""
+}
+
+func (s *syndicationServiceImpl) BuildFeed(query FeedQuery, fallbackSiteURL string, feedURL string) (*syndication.Feed, error) {
+	if query.Mode == "" {
+		query.Mode = FeedModeSummary
+	}
+	if query.Mode != FeedModeSummary && query.Mode != FeedModeFull {
+		return nil, ErrUnknownFeedMode
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultFeedLimit
+	} else if query.Limit > maxFeedLimit {
+		query.Limit = maxFeedLimit
+	}
+	siteURL := s.siteURL
+	if siteURL == "" {
+		siteURL = strings.TrimSuffix(fallbackSiteURL, "/")
+	}
+
+	feed := syndication.Feed{
+		Title:   s.siteTitle,
+		Link:    siteURL + "/",
+		FeedURL: feedURL,
+	}
+	feed.Description = "Latest posts on " + s.siteTitle
+	var filter repositories.BlogFilter
+	if query.Category != "" {
+		category, err := s.categoryRepo.FindBySlug(query.Category)
+		if err != nil {
+			return nil, ErrFeedCategoryNotFound
+		}
+		filter.CategorySlug = category.Slug
+		feed.Title = category.Title + " - " + feed.Title
+		feed.Description = "Latest posts in " + category.Title + " on " + s.siteTitle
+	}
+	if query.Tag != "" {
+		tag, err := s.tagRepo.FindByTitle(query.Tag)
+		if err != nil {
+			return nil, ErrFeedTagNotFound
+		}
+		filter.TagID = tag.ID
+		feed.Title = "#" + tag.Title + " - " + feed.Title
+		feed.Description = "Latest posts tagged " + tag.Title + " on " + s.siteTitle
+	}
+	if query.Author != "" {
+		author, err := s.userRepo.FindByUserName(query.Author)
+		if err != nil {
+			return nil, ErrFeedAuthorNotFound
+		}
+		filter.AuthorID = author.ID
+		feed.Title = "@" + author.UserName + " - " + feed.Title
+		feed.Description = "Latest posts by " + author.UserName + " on " + s.siteTitle
+		feed.Link = siteURL + authorPath(author.UserName)
+	}
+	// The id only depends on the selection, so readers keep the feed across domain moves
+	feed.ID = fmt.Sprintf("urn:yp-blog:feed:category=%s;tag=%s;author=%s",
+		url.QueryEscape(query.Category), url.QueryEscape(query.Tag), url.QueryEscape(query.Author))
+
+	blogs, err := s.blogRepo.FindLatestPublished(filter, query.Limit)
+	if err != nil {
+		return nil, err
+	}
+	feed.Items = make([]syndication.Item, len(blogs))
+	for i, blog := range blogs {
+		feed.Items[i] = toFeedItem(blog, siteURL, query.Mode)
+		if blog.UpdatedAt.After(feed.Updated) {
+			feed.Updated = blog.UpdatedAt
+		}
+	}
+	return &feed, nil
+}
+
+func toFeedItem(blog models.Blog, siteURL string, mode string) syndication.Item {
+	summary := blog.Summary
+	if summary == "" {
+		summary = utils.GenerateSummary(blog.BlogContent, feedSummaryLength)
+	}
+	item := syndication.Item{
+		ID:         fmt.Sprintf("urn:yp-blog:blog:%d", blog.ID),
+		Title:      blog.BlogTitle,
+		Link:       siteURL + blogPath(blog.Author.UserName, blog.Slug),
+		Summary:    summary,
+		AuthorName: blog.Author.UserName,
+		AuthorURL:  siteURL + authorPath(blog.Author.UserName),
+		Image:      blog.Thumbnail,
+		Published:  blog.CreatedAt,
+		Updated:    blog.UpdatedAt,
+		Categories: []string{},
+	}
+	if mode == FeedModeFull {
+		item.ContentHTML = blog.BlogContent
+	}
+	for _, category := range blog.Categories {
+		item.Categories = append(item.Categories, category.Title)
+	}
+	for _, tag := range blog.Tags {
+		item.Categories = append(item.Categories, tag.Title)
+	}
+	return item
+}
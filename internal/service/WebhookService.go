@@ -0,0 +1,443 @@
+package service
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+	"yp-blog-api/internal/dto"
+	"yp-blog-api/internal/mapping"
+	"yp-blog-api/internal/models"
+	"yp-blog-api/internal/repository"
+	"yp-blog-api/internal/utils"
+)
+
+const (
+	// webhookMaxAttempts is how many times a delivery is tried before it is marked failed
+	webhookMaxAttempts = 8
+	// webhookRetryBaseDelay is the wait after the first failed attempt; it doubles with every attempt
+	webhookRetryBaseDelay = 30 * time.Second
+	// webhookRetryMaxDelay caps the wait between two attempts
+	webhookRetryMaxDelay = 6 * time.Hour
+	// webhookRequestTimeout bounds a single request to a receiver
+	webhookRequestTimeout = 10 * time.Second
+	// webhookDeliveryBatchSize is how many due deliveries DeliverDue sends per call
+	webhookDeliveryBatchSize = 50
+
+	defaultWebhookDeliveriesLimit = 50
+	maxWebhookDeliveriesLimit     = 200
+)
+
+// Headers sent with every webhook request. The signature is the hex encoded
+// HMAC-SHA256 of "<timestamp>.<body>" keyed with the webhook secret.
+const (
+	WebhookHeaderEvent     = "X-Webhook-Event"
+	WebhookHeaderDelivery  = "X-Webhook-Delivery"
+	WebhookHeaderTimestamp = "X-Webhook-Timestamp"
+	WebhookHeaderSignature = "X-Webhook-Signature"
+)
+
+var (
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrInvalidWebhookURL       = errors.New("webhook URL must use http or https")
+	ErrUnknownDeliveryStatus   = errors.New("unknown webhook delivery status")
+
+	errWebhookAddressNotAllowed = errors.New("webhook receivers on loopback, private or link-local addresses are not allowed")
+)
+
+// WebhookService manages webhook subscriptions and delivers content events to them
+type WebhookService interface {
+	FindWebhooks() ([]dto.WebhookDto, error)
+	FindWebhook(id uint) (*dto.WebhookDto, error)
+	CreateWebhook(webhookDto dto.WebhookRequestDto) (*dto.WebhookDto, error)
+	UpdateWebhook(id uint, webhookDto dto.WebhookRequestDto) (*dto.WebhookDto, error)
+	DeleteWebhook(id uint) error
+
+	FindDeliveries(webhookId uint, status string, limit int) ([]dto.WebhookDeliveryDto, error)
+	FindDelivery(webhookId uint, deliveryId uint) (*dto.WebhookDeliveryDetailDto, error)
+	Redeliver(webhookId uint, deliveryId uint) (*dto.WebhookDeliveryDto, error)
+
+	// Enqueue schedules the delivery of a blog event to every active webhook subscribed to it
+	Enqueue(event string, blog models.Blog) error
+	// DeliverDue sends the deliveries whose next attempt is due
+	DeliverDue() error
+}
+
+type webhookServiceImpl struct {
+	webhookRepo repositories.WebhookRepository
+	httpClient  *http.Client
+}
+
+// NewWebhookService creates a WebhookService
+func NewWebhookService(webhookRepo repositories.WebhookRepository) WebhookService {
+	return &webhookServiceImpl{
+		webhookRepo: webhookRepo,
+		httpClient:  newWebhookHTTPClient(),
+	}
+}
+
+// newWebhookHTTPClient creates the client requests to receivers are sent with. It only
+// connects to public addresses, checked after name resolution and on every redirect, so
+// webhooks cannot be used to reach the server's own network.
+func newWebhookHTTPClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: webhookRequestTimeout,
+		Control: func(network string, address string, conn syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+				ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+				return fmt.Errorf("%w: %s", errWebhookAddressNotAllowed, host)
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: webhookRequestTimeout,
+		Transport: &http.Transport{
+			// A proxy would be dialed instead of the receiver, bypassing the address check
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: webhookRequestTimeout,
+			MaxIdleConnsPerHost: 2,
+		},
+	}
+}
+
+func (s *webhookServiceImpl) FindWebhooks() ([]dto.WebhookDto, error) {
+	webhooks, err := s.webhookRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	webhookDtos := make([]dto.WebhookDto, len(webhooks))
+	for i, webhook := range webhooks {
+		webhookDtos[i] = toWebhookDto(webhook)
+	}
+	return webhookDtos, nil
+}
+
+func (s *webhookServiceImpl) FindWebhook(id uint) (*dto.WebhookDto, error) {
+	webhook, err := s.webhookRepo.FindById(id)
+	if err != nil {
+		return nil, ErrWebhookNotFound
+	}
+	webhookDto := toWebhookDto(*webhook)
+	return &webhookDto, nil
+}
+
+// CreateWebhook registers a webhook with a new signing secret, which is only returned here
+func (s *webhookServiceImpl) CreateWebhook(webhookDto dto.WebhookRequestDto) (*dto.WebhookDto, error) {
+	if err := validateWebhookRequest(webhookDto); err != nil {
+		return nil, err
+	}
+	secret, err := generateEditToken()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := models.Webhook{Secret: secret, Active: true}
+	applyWebhookRequest(&webhook, webhookDto)
+	if err := s.webhookRepo.Create(&webhook); err != nil {
+		return nil, fmt.Errorf("error saving webhook: %v", err)
+	}
+
+	response := toWebhookDto(webhook)
+	response.Secret = webhook.Secret
+	return &response, nil
+}
+
+// UpdateWebhook changes a webhook; the new secret is returned when it is rotated
+func (s *webhookServiceImpl) UpdateWebhook(id uint, webhookDto dto.WebhookRequestDto) (*dto.WebhookDto, error) {
+	if err := validateWebhookRequest(webhookDto); err != nil {
+		return nil, err
+	}
+	webhook, err := s.webhookRepo.FindById(id)
+	if err != nil {
+		return nil, ErrWebhookNotFound
+	}
+
+	applyWebhookRequest(webhook, webhookDto)
+	if webhookDto.RotateSecret {
+		if webhook.Secret, err = generateEditToken(); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.webhookRepo.Update(webhook); err != nil {
+		return nil, fmt.Errorf("error saving webhook: %v", err)
+	}
+
+	response := toWebhookDto(*webhook)
+	if webhookDto.RotateSecret {
+		response.Secret = webhook.Secret
+	}
+	return &response, nil
+}
+
+func (s *webhookServiceImpl) DeleteWebhook(id uint) error {
+	if _, err := s.webhookRepo.FindById(id); err != nil {
+		return ErrWebhookNotFound
+	}
+	return s.webhookRepo.DeleteById(id)
+}
+
+// FindDeliveries lists the delivery log of a webhook, newest first
+func (s *webhookServiceImpl) FindDeliveries(webhookId uint, status string, limit int) ([]dto.WebhookDeliveryDto, error) {
+	if status != "" && status != models.WebhookDeliveryStatusPending &&
+		status != models.WebhookDeliveryStatusSucceeded && status != models.WebhookDeliveryStatusFailed {
+		return nil, ErrUnknownDeliveryStatus
+	}
+	if limit <= 0 {
+		limit = defaultWebhookDeliveriesLimit
+	} else if limit > maxWebhookDeliveriesLimit {
+		limit = maxWebhookDeliveriesLimit
+	}
+	if _, err := s.webhookRepo.FindById(webhookId); err != nil {
+		return nil, ErrWebhookNotFound
+	}
+
+	deliveries, err := s.webhookRepo.FindDeliveriesByWebhookId(webhookId, status, limit)
+	if err != nil {
+		return nil, err
+	}
+	deliveryDtos := make([]dto.WebhookDeliveryDto, len(deliveries))
+	for i, delivery := range deliveries {
+		deliveryDtos[i] = toWebhookDeliveryDto(delivery)
+	}
+	return deliveryDtos, nil
+}
+
+func (s *webhookServiceImpl) FindDelivery(webhookId uint, deliveryId uint) (*dto.WebhookDeliveryDetailDto, error) {
+	delivery, err := s.webhookRepo.FindDeliveryByIdAndWebhookId(deliveryId, webhookId)
+	if err != nil {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+	detail := dto.WebhookDeliveryDetailDto{
+		WebhookDeliveryDto: toWebhookDeliveryDto(*delivery),
+		Payload:            delivery.Payload,
+		AttemptsLog:        make([]dto.WebhookDeliveryAttemptDto, len(delivery.AttemptLog)),
+	}
+	for i, attempt := range delivery.AttemptLog {
+		detail.AttemptsLog[i] = dto.WebhookDeliveryAttemptDto{
+			StatusCode: attempt.StatusCode,
+			Error:      attempt.Error,
+			DurationMs: attempt.DurationMs,
+			CreatedAt:  mapper.GetTimeAgo(attempt.CreatedAt),
+		}
+	}
+	return &detail, nil
+}
+
+// Redeliver queues a delivery again with a fresh set of attempts, keeping its attempt log
+func (s *webhookServiceImpl) Redeliver(webhookId uint, deliveryId uint) (*dto.WebhookDeliveryDto, error) {
+	delivery, err := s.webhookRepo.FindDeliveryByIdAndWebhookId(deliveryId, webhookId)
+	if err != nil {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+	now := time.Now()
+	delivery.Status = models.WebhookDeliveryStatusPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = &now
+	if err := s.webhookRepo.UpdateDelivery(delivery); err != nil {
+		return nil, err
+	}
+	deliveryDto := toWebhookDeliveryDto(*delivery)
+	return &deliveryDto, nil
+}
+
+func (s *webhookServiceImpl) Enqueue(event string, blog models.Blog) error {
+	webhooks, err := s.webhookRepo.FindAllActive()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	payload, err := json.Marshal(dto.WebhookPayloadDto{Event: event, CreatedAt: now.UTC(), Blog: toBlogEventDto(blog)})
+	if err != nil {
+		return err
+	}
+	var deliveries []models.WebhookDelivery
+	for _, webhook := range webhooks {
+		if containsString(strings.Split(webhook.Events, ","), event) {
+			deliveries = append(deliveries, models.WebhookDelivery{
+				WebhookID:     webhook.ID,
+				Event:         event,
+				Payload:       string(payload),
+				Status:        models.WebhookDeliveryStatusPending,
+				NextAttemptAt: &now,
+			})
+		}
+	}
+	return s.webhookRepo.CreateDeliveries(deliveries)
+}
+
+func (s *webhookServiceImpl) DeliverDue() error {
+	deliveries, err := s.webhookRepo.FindDueDeliveries(time.Now(), webhookDeliveryBatchSize)
+	if err != nil {
+		return err
+	}
+	for i := range deliveries {
+		if err := s.deliver(&deliveries[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliver makes one attempt at a delivery and schedules the next one when it fails
+func (s *webhookServiceImpl) deliver(delivery *models.WebhookDelivery) error {
+	webhook, err := s.webhookRepo.FindById(delivery.WebhookID)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	statusCode, sendErr := s.send(webhook, delivery)
+	attempt := models.WebhookDeliveryAttempt{
+		DeliveryID: delivery.ID,
+		StatusCode: statusCode,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if sendErr != nil {
+		attempt.Error = utils.TruncateRunes(sendErr.Error(), 500)
+	}
+	if err := s.webhookRepo.CreateAttempt(&attempt); err != nil {
+		return err
+	}
+
+	delivery.Attempts++
+	delivery.LastStatusCode = statusCode
+	delivery.LastError = attempt.Error
+	switch {
+	case sendErr == nil:
+		now := time.Now()
+		delivery.Status = models.WebhookDeliveryStatusSucceeded
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+	case delivery.Attempts >= webhookMaxAttempts || !webhook.Active:
+		delivery.Status = models.WebhookDeliveryStatusFailed
+		delivery.NextAttemptAt = nil
+	default:
+		next := time.Now().Add(utils.ExponentialBackoff(webhookRetryBaseDelay, webhookRetryMaxDelay, delivery.Attempts))
+		delivery.NextAttemptAt = &next
+	}
+	return s.webhookRepo.UpdateDelivery(delivery)
+}
+
+// send posts the signed payload and returns the receiver's status code. Any status
+// outside 2xx is an error.
+func (s *webhookServiceImpl) send(webhook *models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewBufferString(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "yp-blog-api-webhooks")
+	req.Header.Set(WebhookHeaderEvent, delivery.Event)
+	req.Header.Set(WebhookHeaderDelivery, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(WebhookHeaderTimestamp, timestamp)
+	req.Header.Set(WebhookHeaderSignature, "sha256="+SignWebhookPayload(webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("receiver responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// SignWebhookPayload computes the signature receivers use to verify a webhook request
+func SignWebhookPayload(secret string, timestamp string, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func validateWebhookRequest(webhookDto dto.WebhookRequestDto) error {
+	if err := webhookDto.Validate(); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+	parsed, err := url.Parse(webhookDto.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return ErrInvalidWebhookURL
+	}
+	return nil
+}
+
+func applyWebhookRequest(webhook *models.Webhook, webhookDto dto.WebhookRequestDto) {
+	webhook.URL = webhookDto.URL
+	webhook.Description = webhookDto.Description
+	webhook.Events = strings.Join(webhookDto.Events, ",")
+	if webhookDto.Active != nil {
+		webhook.Active = *webhookDto.Active
+	}
+}
+
+func toWebhookDto(webhook models.Webhook) dto.WebhookDto {
+	return dto.WebhookDto{
+		ID:          webhook.ID,
+		URL:         webhook.URL,
+		Description: webhook.Description,
+		Events:      strings.Split(webhook.Events, ","),
+		Active:      webhook.Active,
+		CreatedAt:   mapper.GetTimeAgo(webhook.CreatedAt),
+	}
+}
+
+func toWebhookDeliveryDto(delivery models.WebhookDelivery) dto.WebhookDeliveryDto {
+	deliveryDto := dto.WebhookDeliveryDto{
+		ID:             delivery.ID,
+		Event:          delivery.Event,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      mapper.GetTimeAgo(delivery.CreatedAt),
+	}
+	if delivery.NextAttemptAt != nil {
+		deliveryDto.NextAttemptAt = delivery.NextAttemptAt.UTC().Format(time.RFC3339)
+	}
+	return deliveryDto
+}
+
+func toBlogEventDto(blog models.Blog) dto.BlogEventDto {
+	blogEvent := dto.BlogEventDto{
+		ID:         blog.ID,
+		Slug:       blog.Slug,
+		BlogTitle:  blog.BlogTitle,
+		Summary:    blog.Summary,
+		Thumbnail:  blog.Thumbnail,
+		Author:     blog.Author.UserName,
+		Link:       blogPath(blog.Author.UserName, blog.Slug),
+		Categories: []string{},
+		Tags:       []string{},
+		Published:  blog.Published,
+		Deleted:    blog.IsDeleted,
+	}
+	for _, category := range blog.Categories {
+		blogEvent.Categories = append(blogEvent.Categories, category.Slug)
+	}
+	for _, tag := range blog.Tags {
+		blogEvent.Tags = append(blogEvent.Tags, tag.Title)
+	}
+	return blogEvent
+}
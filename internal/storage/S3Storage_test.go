@@ -0,0 +1,224 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testAccessKey = "AKIDEXAMPLE"
+	testSecretKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+)
+
+// TestSignV4 checks signatures against the AWS Signature Version 4 test suite, which
+// signs requests to example.amazonaws.com for the "service" service in us-east-1
+func TestSignV4(t *testing.T) {
+	signedAt := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		method        string
+		url           string
+		headers       map[string]string
+		body          string
+		signedHeaders string
+		signature     string
+	}{
+		{
+			name:          "get-vanilla",
+			method:        http.MethodGet,
+			url:           "https://example.amazonaws.com/",
+			signedHeaders: "host;x-amz-date",
+			signature:     "5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		},
+		{
+			name:          "post-vanilla",
+			method:        http.MethodPost,
+			url:           "https://example.amazonaws.com/",
+			signedHeaders: "host;x-amz-date",
+			signature:     "5da7c1a2acd57cee7505fc6676e4e544621c30862966e37dddb68e92efbe5d6b",
+		},
+		{
+			name:          "get-vanilla-query-order-key-case",
+			method:        http.MethodGet,
+			url:           "https://example.amazonaws.com/?Param2=value2&Param1=value1",
+			signedHeaders: "host;x-amz-date",
+			signature:     "b97d918cfa904a5beff61c982a1b6f458b799221646efd99d3219ec94cdf2500",
+		},
+		{
+			name:          "post-x-www-form-urlencoded",
+			method:        http.MethodPost,
+			url:           "https://example.amazonaws.com/",
+			headers:       map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+			body:          "Param1=value1",
+			signedHeaders: "content-type;host;x-amz-date",
+			signature:     "ff11897932ad3f4e8b18135d722051e5ac45fc38421b1da7b9d196a0fe09473a",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, err := http.NewRequest(test.method, test.url, strings.NewReader(test.body))
+			if err != nil {
+				t.Fatalf("Failed to build the request: %v", err)
+			}
+			for name, value := range test.headers {
+				request.Header.Set(name, value)
+			}
+			SignV4(request, []byte(test.body), testAccessKey, testSecretKey, "us-east-1", "service", signedAt)
+
+			want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=" +
+				test.signedHeaders + ", Signature=" + test.signature
+			if got := request.Header.Get("Authorization"); got != want {
+				t.Errorf("Authorization = %q, want %q", got, want)
+			}
+			if got := request.Header.Get("X-Amz-Date"); got != "20150830T123600Z" {
+				t.Errorf("X-Amz-Date = %q, want 20150830T123600Z", got)
+			}
+		})
+	}
+}
+
+// fakeS3 stands in for an S3-compatible service. It keeps objects in memory and
+// rejects requests whose signature or payload hash does not check out.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	if err := verifyS3Request(r, body); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[r.URL.Path] = body
+	case http.MethodGet:
+		object, found := f.objects[r.URL.Path]
+		if !found {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Write(object)
+	case http.MethodDelete:
+		if _, found := f.objects[r.URL.Path]; !found {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// verifyS3Request signs a copy of the signed part of the request again and compares the result
+func verifyS3Request(r *http.Request, body []byte) error {
+	payloadHash := sha256.Sum256(body)
+	if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(payloadHash[:]) {
+		return errors.New("payload hash does not match")
+	}
+	signedAt, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return errors.New("missing request date")
+	}
+	authorization := r.Header.Get("Authorization")
+	_, signedHeaders, found := strings.Cut(authorization, "SignedHeaders=")
+	if !found {
+		return errors.New("missing signature")
+	}
+	signedHeaders, _, _ = strings.Cut(signedHeaders, ",")
+
+	resigned, err := http.NewRequest(r.Method, "http://"+r.Host+r.URL.Path, nil)
+	if err != nil {
+		return err
+	}
+	for _, name := range strings.Split(signedHeaders, ";") {
+		if name != "host" {
+			resigned.Header.Set(name, r.Header.Get(name))
+		}
+	}
+	SignV4(resigned, body, testAccessKey, testSecretKey, "us-east-1", "s3", signedAt)
+	if resigned.Header.Get("Authorization") != authorization {
+		return errors.New("signature does not match")
+	}
+	return nil
+}
+
+func newTestS3Storage(t *testing.T) (*S3Storage, *fakeS3) {
+	fake := &fakeS3{objects: make(map[string][]byte)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	s3, err := NewS3Storage(S3Config{Endpoint: server.URL, Bucket: "media", AccessKey: testAccessKey, SecretKey: testSecretKey})
+	if err != nil {
+		t.Fatalf("Failed to create the storage: %v", err)
+	}
+	return s3, fake
+}
+
+func TestS3StoragePutGetDelete(t *testing.T) {
+	s3, fake := newTestS3Storage(t)
+	ctx := context.Background()
+	key := "12/photo one.png"
+	content := []byte("not really a png")
+
+	if err := s3.Put(ctx, key, "image/png", bytes.NewReader(content), int64(len(content))); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if _, found := fake.objects["/media/12/photo one.png"]; !found {
+		t.Fatalf("Put stored %v, want the object under the bucket path", fake.objects)
+	}
+
+	object, err := s3.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	got, err := io.ReadAll(object)
+	object.Close()
+	if err != nil || !bytes.Equal(got, content) {
+		t.Errorf("Get returned %q (%v), want %q", got, err, content)
+	}
+
+	if err := s3.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := s3.Get(ctx, key); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Get after Delete returned %v, want ErrObjectNotFound", err)
+	}
+}
+
+func TestS3StorageMissingObject(t *testing.T) {
+	s3, _ := newTestS3Storage(t)
+	ctx := context.Background()
+
+	if _, err := s3.Get(ctx, "12/missing.png"); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Get returned %v, want ErrObjectNotFound", err)
+	}
+	if err := s3.Delete(ctx, "12/missing.png"); err != nil {
+		t.Errorf("Delete of a missing object returned %v, want nil", err)
+	}
+}
+
+func TestS3StorageRejectedRequest(t *testing.T) {
+	s3, _ := newTestS3Storage(t)
+	s3.config.SecretKey = "wrong"
+
+	err := s3.Put(context.Background(), "12/a.png", "image/png", strings.NewReader("a"), 1)
+	if err == nil || !strings.Contains(err.Error(), "status 403") {
+		t.Errorf("Put with a wrong key returned %v, want a 403 error", err)
+	}
+}
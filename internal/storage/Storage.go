@@ -0,0 +1,22 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrObjectNotFound is returned when no object is stored under a key
+var ErrObjectNotFound = errors.New("object not found")
+
+// Storage keeps uploaded files. Keys are slash separated paths such as "12/3f9a.png".
+type Storage interface {
+	// Put stores size bytes read from body under key, replacing any previous object
+	Put(ctx context.Context, key string, contentType string, body io.Reader, size int64) error
+	// Get opens the object stored under key; the caller closes it
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object stored under key; deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+	// URL is the public address of the object stored under key
+	URL(key string) string
+}
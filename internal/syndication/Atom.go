@@ -0,0 +1,98 @@
+package syndication
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// ContentTypeAtom is the media type of Atom documents
+const ContentTypeAtom = "application/atom+xml; charset=utf-8"
+
+type atomFeed struct {
+	XMLName   xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Subtitle  string      `xml:"subtitle,omitempty"`
+	Updated   string      `xml:"updated"`
+	Links     []atomLink  `xml:"link"`
+	Generator string      `xml:"generator"`
+	Entries   []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Links      []atomLink     `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Author     *atomPerson    `xml:"author,omitempty"`
+	Categories []atomCategory `xml:"category"`
+	Summary    *atomText      `xml:"summary,omitempty"`
+	Content    *atomText      `xml:"content,omitempty"`
+}
+
+// EncodeAtom renders the feed as Atom 1.0. Full content is sent as HTML content,
+// the summary as a text summary.
+func EncodeAtom(feed Feed) ([]byte, error) {
+	updated := feed.Updated
+	if updated.IsZero() {
+		// updated is required; an empty feed has never changed
+		updated = time.Unix(0, 0)
+	}
+	document := atomFeed{
+		ID:       feed.ID,
+		Title:    feed.Title,
+		Subtitle: feed.Description,
+		Updated:  updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: feed.Link, Rel: "alternate", Type: "text/html"},
+			{Href: feed.FeedURL, Rel: "self", Type: "application/atom+xml"},
+		},
+		Generator: Generator,
+		Entries:   make([]atomEntry, len(feed.Items)),
+	}
+	for i, item := range feed.Items {
+		entry := atomEntry{
+			ID:         item.ID,
+			Title:      item.Title,
+			Links:      []atomLink{{Href: item.Link, Rel: "alternate", Type: "text/html"}},
+			Published:  item.Published.UTC().Format(time.RFC3339),
+			Updated:    item.Updated.UTC().Format(time.RFC3339),
+			Categories: make([]atomCategory, len(item.Categories)),
+		}
+		if item.AuthorName != "" {
+			entry.Author = &atomPerson{Name: item.AuthorName, URI: item.AuthorURL}
+		}
+		for j, category := range item.Categories {
+			entry.Categories[j] = atomCategory{Term: category}
+		}
+		if item.Summary != "" {
+			entry.Summary = &atomText{Type: "text", Value: item.Summary}
+		}
+		if item.ContentHTML != "" {
+			entry.Content = &atomText{Type: "html", Value: item.ContentHTML}
+		}
+		document.Entries[i] = entry
+	}
+	return encodeXML(document)
+}
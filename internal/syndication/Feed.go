@@ -0,0 +1,36 @@
+package syndication
+
+import (
+	"time"
+)
+
+// Feed is a format-neutral description of a syndication feed
+type Feed struct {
+	// ID identifies the feed for formats that need a stable id (Atom)
+	ID          string
+	Title       string
+	Description string
+	// Link is the HTML page the feed belongs to
+	Link string
+	// FeedURL is the URL the feed itself is served from
+	FeedURL string
+	Updated time.Time
+	Items   []Item
+}
+
+// Item is one entry of a Feed
+type Item struct {
+	// ID is stable across edits, so readers do not show an updated post twice
+	ID      string
+	Title   string
+	Link    string
+	Summary string
+	// ContentHTML is the full post; it is left empty for summary-only feeds
+	ContentHTML string
+	AuthorName  string
+	AuthorURL   string
+	Categories  []string
+	Image       string
+	Published   time.Time
+	Updated     time.Time
+}
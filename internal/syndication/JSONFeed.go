@@ -0,0 +1,75 @@
+package syndication
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	// ContentTypeJSONFeed is the media type of JSON Feed documents
+	ContentTypeJSONFeed = "application/feed+json; charset=utf-8"
+	// Generator names this API in the feeds it renders
+	Generator = "yp-blog-api"
+)
+
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url,omitempty"`
+	FeedURL     string         `json:"feed_url,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+type jsonFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url"`
+	Title         string           `json:"title"`
+	ContentHTML   string           `json:"content_html,omitempty"`
+	ContentText   string           `json:"content_text,omitempty"`
+	Summary       string           `json:"summary,omitempty"`
+	Image         string           `json:"image,omitempty"`
+	DatePublished string           `json:"date_published"`
+	DateModified  string           `json:"date_modified"`
+	Authors       []jsonFeedAuthor `json:"authors,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+}
+
+// EncodeJSONFeed renders the feed as JSON Feed 1.1. Items need either content_html
+// or content_text, so summary-only items carry the summary as their text content.
+func EncodeJSONFeed(feed Feed) ([]byte, error) {
+	document := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       feed.Title,
+		HomePageURL: feed.Link,
+		FeedURL:     feed.FeedURL,
+		Description: feed.Description,
+		Items:       make([]jsonFeedItem, len(feed.Items)),
+	}
+	for i, item := range feed.Items {
+		jsonItem := jsonFeedItem{
+			ID:            item.ID,
+			URL:           item.Link,
+			Title:         item.Title,
+			ContentHTML:   item.ContentHTML,
+			Summary:       item.Summary,
+			Image:         item.Image,
+			DatePublished: item.Published.UTC().Format(time.RFC3339),
+			DateModified:  item.Updated.UTC().Format(time.RFC3339),
+			Tags:          item.Categories,
+		}
+		if jsonItem.ContentHTML == "" {
+			jsonItem.ContentText = item.Summary
+		}
+		if item.AuthorName != "" {
+			jsonItem.Authors = []jsonFeedAuthor{{Name: item.AuthorName, URL: item.AuthorURL}}
+		}
+		document.Items[i] = jsonItem
+	}
+	return json.MarshalIndent(document, "", "  ")
+}
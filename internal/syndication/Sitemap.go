@@ -0,0 +1,59 @@
+package syndication
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+const (
+	// ContentTypeSitemap is the media type of sitemaps and sitemap indexes
+	ContentTypeSitemap = "application/xml; charset=utf-8"
+	// MaxSitemapURLs is how many URLs the sitemap protocol allows in one sitemap
+	MaxSitemapURLs = 50000
+
+	sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+)
+
+// SitemapURL is a page listed in a sitemap, or a sitemap listed in a sitemap index
+type SitemapURL struct {
+	Loc     string
+	LastMod time.Time
+}
+
+type sitemapLocation struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name          `xml:"urlset"`
+	Xmlns   string            `xml:"xmlns,attr"`
+	URLs    []sitemapLocation `xml:"url"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name          `xml:"sitemapindex"`
+	Xmlns    string            `xml:"xmlns,attr"`
+	Sitemaps []sitemapLocation `xml:"sitemap"`
+}
+
+// EncodeSitemap renders a sitemap of at most MaxSitemapURLs pages
+func EncodeSitemap(urls []SitemapURL) ([]byte, error) {
+	return encodeXML(sitemapURLSet{Xmlns: sitemapNamespace, URLs: toSitemapLocations(urls)})
+}
+
+// EncodeSitemapIndex renders a sitemap index pointing at the given sitemaps
+func EncodeSitemapIndex(sitemaps []SitemapURL) ([]byte, error) {
+	return encodeXML(sitemapIndex{Xmlns: sitemapNamespace, Sitemaps: toSitemapLocations(sitemaps)})
+}
+
+func toSitemapLocations(urls []SitemapURL) []sitemapLocation {
+	locations := make([]sitemapLocation, len(urls))
+	for i, url := range urls {
+		locations[i] = sitemapLocation{Loc: url.Loc}
+		if !url.LastMod.IsZero() {
+			locations[i].LastMod = url.LastMod.UTC().Format(time.RFC3339)
+		}
+	}
+	return locations
+}
@@ -0,0 +1,16 @@
+package utils
+
+import "time"
+
+// ExponentialBackoff returns the wait before the attempt that follows the given number
+// of failed attempts: base after the first one, doubling with every attempt, capped at maxDelay
+func ExponentialBackoff(base time.Duration, maxDelay time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
@@ -1,13 +1,19 @@
 package utils
 
 import (
+	"crypto/rand"
 	"fmt"
 	"github.com/gosimple/slug"
-	"math/rand"
+	"math/big"
+	"regexp"
 	"strings"
-	"time"
 )
 
+// MaxSlugLength leaves room in the 256 character slug column for collision suffixes
+const MaxSlugLength = 200
+
+var slugFormat = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
 // ContainsKhmer checks if a string contains Khmer characters
 func ContainsKhmer(input string) bool {
 	if input == "" {
@@ -35,8 +41,17 @@ func RemoveKhmerCharacters(input string) string {
 
 // GenerateUniqueIdentifier Generates a 9-digit unique identifier
 func GenerateUniqueIdentifier() string {
-	rand.Int63n(time.Now().UnixNano())
-	return fmt.Sprintf("%09d", rand.Intn(1e9))
+	n, err := rand.Int(rand.Reader, big.NewInt(1e9))
+	if err != nil {
+		// crypto/rand only fails when the OS entropy source is unavailable
+		panic(fmt.Sprintf("failed to generate unique identifier: %v", err))
+	}
+	return fmt.Sprintf("%09d", n.Int64())
+}
+
+// IsValidSlug checks that a slug is made of lowercase letters and digits separated by single hyphens
+func IsValidSlug(input string) bool {
+	return len(input) <= MaxSlugLength && slugFormat.MatchString(input)
 }
 
 // Init generates a slug using the simple/slug package
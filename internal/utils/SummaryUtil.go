@@ -0,0 +1,206 @@
+package utils
+
+import (
+	"html"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	textRankDamping    = 0.85
+	textRankIterations = 30
+	textRankTolerance  = 1e-4
+	// textRankMaxSentences bounds the similarity graph, which grows with the square of
+	// the sentences ranked; a summary of a few hundred characters is found well before
+	textRankMaxSentences = 200
+)
+
+var (
+	// Sentences end with Latin punctuation, the Khmer khan (។) and bariyoosan (៕), or a line break
+	sentenceBoundaryPattern = regexp.MustCompile(`[^.!?\x{17D4}\x{17D5}\n]+[.!?\x{17D4}\x{17D5}]*`)
+	blockTagPattern         = regexp.MustCompile(`(?i)</?(p|div|br|li|h[1-6]|blockquote|pre|tr)[^>]*>`)
+)
+
+// GenerateSummary builds an extractive summary of an HTML or plain-text document.
+// Sentences are ranked with TextRank and the best ones are returned in document
+// order, keeping the result within maxLength characters. Only the first
+// textRankMaxSentences sentences of long documents are considered.
+func GenerateSummary(content string, maxLength int) string {
+	// Headings are labels rather than sentences, so they are left out of the summary
+	sentences := SplitSentences(StripHTML(headingPattern.ReplaceAllString(content, "\n")))
+	if len(sentences) == 0 || maxLength <= 0 {
+		return ""
+	}
+	if len(sentences) > textRankMaxSentences {
+		sentences = sentences[:textRankMaxSentences]
+	}
+
+	scores := rankSentences(sentences)
+	order := make([]int, len(sentences))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return scores[order[a]] > scores[order[b]]
+	})
+
+	selected := make([]bool, len(sentences))
+	length := 0
+	for _, i := range order {
+		sentenceLength := utf8.RuneCountInString(sentences[i])
+		if length > 0 {
+			sentenceLength++ // joining space
+		}
+		if length+sentenceLength > maxLength {
+			continue
+		}
+		selected[i] = true
+		length += sentenceLength
+	}
+
+	var parts []string
+	for i, sentence := range sentences {
+		if selected[i] {
+			parts = append(parts, sentence)
+		}
+	}
+	if len(parts) == 0 {
+		// Even the best sentence is too long, so cut it at a word boundary
+		return TruncateRunes(sentences[order[0]], maxLength)
+	}
+	return strings.Join(parts, " ")
+}
+
+// StripHTML removes markup and entities from content, keeping block boundaries as line breaks
+func StripHTML(content string) string {
+	text := blockTagPattern.ReplaceAllString(content, "\n")
+	text = html.UnescapeString(tagPattern.ReplaceAllString(text, ""))
+	return strings.ReplaceAll(text, "\u00a0", " ")
+}
+
+// SplitSentences splits plain text into trimmed, non-empty sentences
+func SplitSentences(text string) []string {
+	var sentences []string
+	for _, match := range sentenceBoundaryPattern.FindAllString(text, -1) {
+		sentence := strings.TrimSpace(whitespaceRegex.ReplaceAllString(match, " "))
+		if hasWordContent(sentence) {
+			sentences = append(sentences, sentence)
+		}
+	}
+	return sentences
+}
+
+// rankSentences scores sentences with TextRank over a token-overlap similarity graph
+func rankSentences(sentences []string) []float64 {
+	n := len(sentences)
+	tokens := make([]map[string]bool, n)
+	for i, sentence := range sentences {
+		tokens[i] = TextTokens(sentence)
+	}
+
+	weights := make([][]float64, n)
+	outSum := make([]float64, n)
+	for i := range weights {
+		weights[i] = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			similarity := sentenceSimilarity(tokens[i], tokens[j])
+			weights[i][j] = similarity
+			weights[j][i] = similarity
+			outSum[i] += similarity
+			outSum[j] += similarity
+		}
+	}
+
+	scores := make([]float64, n)
+	for i := range scores {
+		scores[i] = 1
+	}
+	for iteration := 0; iteration < textRankIterations; iteration++ {
+		delta := 0.0
+		next := make([]float64, n)
+		for i := 0; i < n; i++ {
+			rank := 0.0
+			for j := 0; j < n; j++ {
+				if weights[j][i] > 0 {
+					rank += weights[j][i] / outSum[j] * scores[j]
+				}
+			}
+			next[i] = (1 - textRankDamping) + textRankDamping*rank
+			delta += math.Abs(next[i] - scores[i])
+		}
+		scores = next
+		if delta < textRankTolerance {
+			break
+		}
+	}
+	return scores
+}
+
+// sentenceSimilarity is the TextRank overlap measure normalised by sentence length
+func sentenceSimilarity(a, b map[string]bool) float64 {
+	if len(a) < 2 || len(b) < 2 {
+		return 0
+	}
+	overlap := 0
+	for token := range a {
+		if b[token] {
+			overlap++
+		}
+	}
+	if overlap == 0 {
+		return 0
+	}
+	return float64(overlap) / (math.Log(float64(len(a))) + math.Log(float64(len(b))))
+}
+
+// TextTokens lowercases text into a token set. Khmer is written without spaces
+// between words, so Khmer runs are tokenised into character bigrams instead.
+func TextTokens(text string) map[string]bool {
+	tokens := make(map[string]bool)
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(unicode.IsLetter(r) || unicode.IsDigit(r) || isKhmerWordRune(r))
+	})
+	for _, field := range fields {
+		if !ContainsKhmer(field) {
+			tokens[field] = true
+			continue
+		}
+		runes := []rune(field)
+		if len(runes) == 1 {
+			tokens[field] = true
+		}
+		for i := 0; i+1 < len(runes); i++ {
+			tokens[string(runes[i:i+2])] = true
+		}
+	}
+	return tokens
+}
+
+// hasWordContent reports whether s contains at least one letter or digit
+func hasWordContent(s string) bool {
+	return strings.IndexFunc(s, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || isKhmerWordRune(r)
+	}) >= 0
+}
+
+// TruncateRunes shortens s to at most maxLength characters, preferring a space boundary
+func TruncateRunes(s string, maxLength int) string {
+	runes := []rune(s)
+	if len(runes) <= maxLength {
+		return s
+	}
+	cut := maxLength - 1 // leave room for the ellipsis
+	for i := cut; i > maxLength/2; i-- {
+		if unicode.IsSpace(runes[i]) {
+			cut = i
+			break
+		}
+	}
+	return strings.TrimSpace(string(runes[:cut])) + "…"
+}
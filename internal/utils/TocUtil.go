@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// TocHeading is a single entry of a generated table of contents
+type TocHeading struct {
+	Level    int
+	Text     string
+	Anchor   string
+	Children []TocHeading
+}
+
+var (
+	headingPattern  = regexp.MustCompile(`(?is)<h([1-6])(\s[^>]*)?>(.*?)</h([1-6])\s*>`)
+	idAttrPattern   = regexp.MustCompile(`(?i)\sid\s*=\s*("([^"]*)"|'([^']*)')`)
+	tagPattern      = regexp.MustCompile(`(?s)<[^>]*>`)
+	whitespaceRegex = regexp.MustCompile(`\s+`)
+)
+
+// BuildTableOfContents extracts the headings of an HTML document into a nested
+// table of contents and returns the document with matching id attributes injected.
+// Headings that already carry an id keep it so hand-written links do not break.
+func BuildTableOfContents(content string) (string, []TocHeading) {
+	var flat []TocHeading
+	used := make(map[string]int)
+
+	rendered := headingPattern.ReplaceAllStringFunc(content, func(match string) string {
+		parts := headingPattern.FindStringSubmatch(match)
+		// RE2 has no back-references, so mismatched tags like <h2>...</h3> are skipped here
+		if parts[1] != parts[4] {
+			return match
+		}
+		level := int(parts[1][0] - '0')
+		attrs := parts[2]
+		text := headingText(parts[3])
+		if text == "" {
+			return match
+		}
+
+		anchor := ""
+		if existing := idAttrPattern.FindStringSubmatch(attrs); existing != nil {
+			anchor = existing[2] + existing[3]
+		}
+		if anchor == "" {
+			anchor = uniqueAnchor(AnchorFromText(text), used)
+			attrs = fmt.Sprintf(` id="%s"`, html.EscapeString(anchor)) + idAttrPattern.ReplaceAllString(attrs, "")
+		} else {
+			used[anchor]++
+		}
+
+		flat = append(flat, TocHeading{Level: level, Text: text, Anchor: anchor})
+		return fmt.Sprintf("<h%d%s>%s</h%d>", level, attrs, parts[3], level)
+	})
+
+	return rendered, nestHeadings(flat)
+}
+
+// AnchorFromText builds a fragment identifier from heading text. Latin text is
+// slugified as usual while Khmer letters are kept as-is, since transliterating
+// them produces ids that change whenever the transliteration table does.
+func AnchorFromText(text string) string {
+	if !ContainsKhmer(text) {
+		return Init(text)
+	}
+
+	var b strings.Builder
+	pendingDash := false
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case isKhmerWordRune(r) || unicode.IsLetter(r) || unicode.IsDigit(r):
+			if pendingDash && b.Len() > 0 {
+				b.WriteRune('-')
+			}
+			pendingDash = false
+			b.WriteRune(r)
+		case r == '\u200B' || r == '\u200C' || r == '\u200D':
+			// Zero-width characters are used as word breaks in Khmer text
+			continue
+		default:
+			pendingDash = true
+		}
+	}
+	return b.String()
+}
+
+// isKhmerWordRune reports whether r is a Khmer letter, vowel sign or digit
+// (everything in the Khmer block except its punctuation marks)
+func isKhmerWordRune(r rune) bool {
+	return r >= '\u1780' && r <= '\u17FF' && !(r >= '\u17D4' && r <= '\u17DA')
+}
+
+// headingText strips nested markup and entities from the inner HTML of a heading
+func headingText(inner string) string {
+	text := html.UnescapeString(tagPattern.ReplaceAllString(inner, ""))
+	return strings.TrimSpace(whitespaceRegex.ReplaceAllString(text, " "))
+}
+
+// uniqueAnchor suffixes duplicate anchors with their occurrence number
+func uniqueAnchor(anchor string, used map[string]int) string {
+	if anchor == "" {
+		anchor = "section"
+	}
+	candidate := anchor
+	for n := 1; used[candidate] > 0; n++ {
+		candidate = fmt.Sprintf("%s-%d", anchor, n)
+	}
+	used[candidate]++
+	return candidate
+}
+
+// nestHeadings turns a flat, document-ordered list of headings into a tree where
+// each heading owns the deeper headings that follow it
+func nestHeadings(flat []TocHeading) []TocHeading {
+	var result []TocHeading
+	for i := 0; i < len(flat); {
+		heading := flat[i]
+		j := i + 1
+		for j < len(flat) && flat[j].Level > heading.Level {
+			j++
+		}
+		heading.Children = nestHeadings(flat[i+1 : j])
+		result = append(result, heading)
+		i = j
+	}
+	return result
+}
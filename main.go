@@ -1,9 +1,14 @@
 package main
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
+	"time"
+	// Banner campaigns are planned in IANA time zones, which some hosts do not ship
+	_ "time/tzdata"
 	"yp-blog-api/docs"
 
 	"github.com/joho/godotenv"
@@ -11,14 +16,20 @@ import (
 	"yp-blog-api/internal/api"
 	"yp-blog-api/internal/config"
 	"yp-blog-api/internal/mapping"
+	"yp-blog-api/internal/middleware"
 	"yp-blog-api/internal/models"
+	"yp-blog-api/internal/pubsub"
 	"yp-blog-api/internal/repository"
 	"yp-blog-api/internal/service"
+	"yp-blog-api/internal/storage"
 )
 
 // @title backend service for blog api
 // @version 1.0
 // @description backend service api restfull using Gin framework
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
 func main() {
 	// Load environment variables from the .env file
 	err := godotenv.Load(".env." + os.Getenv("APP_ENV"))
@@ -41,7 +52,12 @@ func main() {
 	defer config.CloseDatabase()
 
 	// AutoMigrate to create/update the schema
-	err = config.DB.AutoMigrate(&models.Blog{}, &models.User{}, &models.Tag{}, &models.Category{}, &models.AdvertisingBanner{})
+	err = config.DB.AutoMigrate(&models.Blog{}, &models.User{}, &models.Tag{}, &models.Category{}, &models.AdvertisingBanner{}, &models.BannerDailyStat{},
+		&models.BlogSlugHistory{}, &models.UserNameHistory{}, &models.Comment{},
+		&models.CommentModerationRule{}, &models.Reaction{}, &models.Bookmark{}, &models.ReadingList{},
+		&models.ReadingListItem{}, &models.Follow{}, &models.TagFollow{}, &models.Notification{},
+		&models.NotificationPreference{}, &models.Webhook{}, &models.WebhookDelivery{},
+		&models.WebhookDeliveryAttempt{}, &models.OutboxEvent{}, &models.Job{}, &models.SitemapEntry{}, &models.Media{}, &models.MediaVariant{}, &models.MediaReference{})
 	if err != nil {
 		log.Fatalf("Failed to migrate database: %v", err)
 	}
@@ -50,17 +66,160 @@ func main() {
 	bannerRepo := repositories.NewAdvertisingBannerRepository(config.DB)
 	tagRepo := repositories.NewTagRepository(config.DB)
 	categoryRepo := repositories.NewCategoryRepository(config.DB)
+	commentRepo := repositories.NewCommentRepository(config.DB)
+	userRepo := repositories.NewUserRepository(config.DB)
+	reactionRepo := repositories.NewReactionRepository(config.DB)
+	bookmarkRepo := repositories.NewBookmarkRepository(config.DB)
+	readingListRepo := repositories.NewReadingListRepository(config.DB)
+	followRepo := repositories.NewFollowRepository(config.DB)
+	notificationRepo := repositories.NewNotificationRepository(config.DB)
+	webhookRepo := repositories.NewWebhookRepository(config.DB)
+	outboxRepo := repositories.NewOutboxRepository(config.DB)
+	jobRepo := repositories.NewJobRepository(config.DB)
+	sitemapRepo := repositories.NewSitemapRepository(config.DB)
+	mediaRepo := repositories.NewMediaRepository(config.DB)
 
 	// Initialize the mappers
 	blogMapper := mapper.NewBlogMapper()
 	bannerMapper := mapper.NewAdvertisingBannerMapper()
+	commentMapper := mapper.NewCommentMapper()
 	blogRepo := repositories.NewBlogRepository(config.DB, blogMapper)
 
+	// Live events are published on an in-process bus and streamed to clients
+	bus := pubsub.NewBus()
+
+	// Background jobs that are still running after the visibility timeout are run again
+	jobVisibilityTimeout, err := time.ParseDuration(os.Getenv("JOB_VISIBILITY_TIMEOUT"))
+	if err != nil || jobVisibilityTimeout <= 0 {
+		jobVisibilityTimeout = 5 * time.Minute
+	}
+	jobQueue := service.NewJobQueue(jobRepo, jobVisibilityTimeout)
+
+	// Uploads go to the local filesystem or to an S3-compatible bucket, see MEDIA_STORAGE
+	mediaStorage, err := newMediaStorage()
+	if err != nil {
+		log.Fatalf("Failed to set up media storage: %v", err)
+	}
+	maxUploadBytes, err := strconv.ParseInt(os.Getenv("MEDIA_MAX_UPLOAD_BYTES"), 10, 64)
+	if err != nil || maxUploadBytes <= 0 {
+		maxUploadBytes = 10 << 20
+	}
+
+	// Banners go after every BANNER_EVERY blog cards of a listing, at most BANNER_MAX_PER_PAGE
+	// of them; set BANNER_TRAILING to let a banner end the listing
+	bannerEvery, err := strconv.Atoi(os.Getenv("BANNER_EVERY"))
+	if err != nil || bannerEvery <= 0 {
+		bannerEvery = 3
+	}
+	bannerMaxPerPage, err := strconv.Atoi(os.Getenv("BANNER_MAX_PER_PAGE"))
+	if err != nil || bannerMaxPerPage < 0 {
+		bannerMaxPerPage = 0
+	}
+	bannerTrailing, _ := strconv.ParseBool(os.Getenv("BANNER_TRAILING"))
+	bannerPlacement := service.BannerPlacement{Every: bannerEvery, MaxPerPage: bannerMaxPerPage, Trailing: bannerTrailing}
+
 	// Initialize the service with all required dependencies
-	blogService := service.NewBlogService(blogRepo, bannerRepo, blogMapper, bannerMapper, categoryRepo, tagRepo)
+	notificationService := service.NewNotificationService(notificationRepo, followRepo, userRepo, bus)
+	webhookService := service.NewWebhookService(webhookRepo)
+	// Side effects of content changes are recorded in the outbox and handled by the dispatcher
+	outboxDispatcher := service.NewOutboxDispatcher(outboxRepo)
+	mediaService := service.NewMediaService(mediaRepo, mediaStorage, jobQueue, maxUploadBytes)
+	// Variants of uploads are generated in the background; catch up on any that never were
+	if err := mediaService.ScheduleMissingVariants(); err != nil {
+		log.Printf("Error occurred while scheduling media variants: %v", err)
+	}
+	// Reaction types can be configured as a comma separated list
+	reactionService := service.NewReactionService(reactionRepo, blogRepo, strings.Split(os.Getenv("REACTION_TYPES"), ","))
+	blogService := service.NewBlogService(blogRepo, bannerRepo, blogMapper, bannerMapper, categoryRepo, tagRepo, commentRepo, reactionService,
+		bookmarkRepo, followRepo, notificationService, bus, webhookService, outboxDispatcher, mediaService, bannerPlacement)
+	// Feed links point at SITE_URL, or at the API's own address when it is not set
+	syndicationService := service.NewSyndicationService(blogRepo, categoryRepo, tagRepo, userRepo, os.Getenv("SITE_URL"), os.Getenv("SITE_TITLE"))
+	bannerService := service.NewAdvertisingBannerService(bannerRepo, categoryRepo, bannerMapper)
+	seoService := service.NewSeoService(blogRepo, blogMapper, os.Getenv("SITE_URL"), os.Getenv("SITE_TITLE"))
+	oEmbedService := service.NewOEmbedService(blogRepo, mediaService, os.Getenv("SITE_URL"), os.Getenv("SITE_TITLE"))
+	sitemapService := service.NewSitemapService(sitemapRepo, blogRepo, outboxDispatcher, jobQueue, os.Getenv("SITE_URL"))
+	// Sitemap entries are kept up to date as blogs change; a rebuild on startup catches
+	// anything that changed without an event, like renamed authors
+	if _, err := sitemapService.ScheduleRebuild(); err != nil {
+		log.Printf("Error occurred while scheduling the sitemap rebuild: %v", err)
+	}
+	// Extra spam phrases can be configured as a comma separated list
+	spamBlocklist := strings.Split(os.Getenv("COMMENT_SPAM_BLOCKLIST"), ",")
+	commentService := service.NewCommentService(commentRepo, blogRepo, userRepo, commentMapper, spamBlocklist, notificationService, bus)
+	bookmarkService := service.NewBookmarkService(bookmarkRepo, readingListRepo, blogRepo, blogService)
+	followService := service.NewFollowService(followRepo, userRepo, tagRepo, blogRepo, blogService)
+
+	// Readers sign in with access tokens issued by the account service, signed with JWT_SECRET
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		log.Println("JWT_SECRET is not set, signed-in features are disabled")
+	}
+	// Administrators are the users whose email is listed in ADMIN_EMAILS, comma separated
+	authenticate := middleware.Authenticate(userRepo, jwtSecret, strings.Split(os.Getenv("ADMIN_EMAILS"), ","))
+
+	// Behind a reverse proxy, list its addresses or CIDRs in TRUSTED_PROXIES, comma
+	// separated, so the client IP is read from its X-Forwarded-For; without it, the
+	// connection's address is used. On Fly.io set CLIENT_IP_HEADER=Fly-Client-IP instead.
+	var trustedProxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			trustedProxies = append(trustedProxies, proxy)
+		}
+	}
 
 	// Set up the router with the initialized service
-	router := api.SetupRouter(blogService)
+	router := api.SetupRouter(blogService, commentService, reactionService, bookmarkService, followService, notificationService, webhookService, jobQueue, syndicationService, sitemapService, seoService, oEmbedService, mediaService, maxUploadBytes, bannerService, bus, authenticate,
+		trustedProxies, os.Getenv("CLIENT_IP_HEADER"))
+
+	// Uploads kept on the local filesystem are served by the API itself
+	if localStorage, ok := mediaStorage.(*storage.LocalStorage); ok {
+		router.Static("/media", localStorage.Dir)
+	}
+
+	// Periodically write the views counted in memory to the database
+	viewFlushInterval, err := time.ParseDuration(os.Getenv("VIEW_FLUSH_INTERVAL"))
+	if err != nil || viewFlushInterval <= 0 {
+		viewFlushInterval = time.Minute
+	}
+	go func() {
+		for range time.Tick(viewFlushInterval) {
+			if err := blogService.FlushViewCounts(); err != nil {
+				log.Printf("Error occurred while flushing view counts: %v", err)
+			}
+		}
+	}()
+
+	// Hand the events recorded in the outbox to their handlers, including the ones
+	// left over from before a restart
+	outboxDispatchInterval, err := time.ParseDuration(os.Getenv("OUTBOX_DISPATCH_INTERVAL"))
+	if err != nil || outboxDispatchInterval <= 0 {
+		outboxDispatchInterval = 5 * time.Second
+	}
+	go outboxDispatcher.Run(outboxDispatchInterval)
+
+	// Periodically send the webhook deliveries that are due
+	webhookDeliveryInterval, err := time.ParseDuration(os.Getenv("WEBHOOK_DELIVERY_INTERVAL"))
+	if err != nil || webhookDeliveryInterval <= 0 {
+		webhookDeliveryInterval = 10 * time.Second
+	}
+	go func() {
+		for range time.Tick(webhookDeliveryInterval) {
+			if err := webhookService.DeliverDue(); err != nil {
+				log.Printf("Error occurred while delivering webhooks: %v", err)
+			}
+		}
+	}()
+
+	// Start the background job workers; idle workers poll for ready jobs
+	jobWorkers, err := strconv.Atoi(os.Getenv("JOB_WORKERS"))
+	if err != nil || jobWorkers <= 0 {
+		jobWorkers = 1
+	}
+	jobPollInterval, err := time.ParseDuration(os.Getenv("JOB_POLL_INTERVAL"))
+	if err != nil || jobPollInterval <= 0 {
+		jobPollInterval = 5 * time.Second
+	}
+	jobQueue.Run(jobWorkers, jobPollInterval)
 
 	// Get the port from the environment variables
 	port := os.Getenv("APP_PORT")
@@ -74,3 +233,33 @@ func main() {
 		log.Fatalf("Failed to start the server: %v", err)
 	}
 }
+
+// newMediaStorage creates the storage configured by MEDIA_STORAGE: "local" (the default)
+// keeps uploads in MEDIA_LOCAL_DIR, served at /media; "s3" keeps them in the
+// MEDIA_S3_BUCKET bucket of any S3-compatible service. MEDIA_PUBLIC_URL overrides the
+// address uploads are linked with, e.g. to put a CDN in front of them.
+func newMediaStorage() (storage.Storage, error) {
+	switch os.Getenv("MEDIA_STORAGE") {
+	case "", "local":
+		dir := os.Getenv("MEDIA_LOCAL_DIR")
+		if dir == "" {
+			dir = "uploads"
+		}
+		publicURL := os.Getenv("MEDIA_PUBLIC_URL")
+		if publicURL == "" {
+			publicURL = "/media"
+		}
+		return storage.NewLocalStorage(dir, publicURL)
+	case "s3":
+		return storage.NewS3Storage(storage.S3Config{
+			Endpoint:  os.Getenv("MEDIA_S3_ENDPOINT"),
+			Region:    os.Getenv("MEDIA_S3_REGION"),
+			Bucket:    os.Getenv("MEDIA_S3_BUCKET"),
+			AccessKey: os.Getenv("MEDIA_S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("MEDIA_S3_SECRET_KEY"),
+			PublicURL: os.Getenv("MEDIA_PUBLIC_URL"),
+		})
+	default:
+		return nil, fmt.Errorf("unknown MEDIA_STORAGE %q, expected local or s3", os.Getenv("MEDIA_STORAGE"))
+	}
+}